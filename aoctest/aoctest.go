@@ -0,0 +1,187 @@
+// Package aoctest checks solutions registered in the registry against worked
+// examples from puzzle statements and against known answers to private inputs,
+// and benchmarks them.
+package aoctest
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+// Example is a worked example from a puzzle statement. Not every example
+// covers both parts, so an empty answer is not checked.
+type Example struct {
+	Name  string
+	Input string
+	Part1 string
+	Part2 string
+}
+
+// Examples runs registered solvers of the day against every example.
+func Examples(t *testing.T, day int, examples []Example) {
+	t.Helper()
+
+	parts := lookup(t, day)
+
+	for i, example := range examples {
+		name := example.Name
+		if name == "" {
+			name = fmt.Sprintf("example-%d", i+1)
+		}
+
+		for part, want := range []string{example.Part1, example.Part2} {
+			if want == "" || part >= len(parts) {
+				continue
+			}
+
+			t.Run(fmt.Sprintf("%s/part%d", name, part+1), func(t *testing.T) {
+				Check(t, parts[part], example.Input, want)
+			})
+		}
+	}
+}
+
+// Check runs a single solver on the input and compares its answer to want.
+func Check(t *testing.T, solve registry.Solver, input string, want string) {
+	t.Helper()
+
+	got, err := solve(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("solve: %v", err)
+	}
+
+	if s := fmt.Sprint(got); s != want {
+		t.Errorf("got %s, want %s", s, want)
+	}
+}
+
+// Answers runs registered solvers of the day against the private input
+// input/day-NN.txt, and compares results to input/day-NN.answer, which holds
+// an answer to each part on a separate line. The test is skipped when either
+// of the files is absent.
+func Answers(t *testing.T, day int) {
+	t.Helper()
+
+	parts := lookup(t, day)
+
+	input, err := os.ReadFile(InputPath(t, day, "txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no private input for day %d", day)
+	} else if err != nil {
+		t.Fatalf("read input: %v", err)
+	}
+
+	answers, err := readAnswers(InputPath(t, day, "answer"))
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no answers for day %d", day)
+	} else if err != nil {
+		t.Fatalf("read answers: %v", err)
+	}
+
+	for i, want := range answers {
+		if i >= len(parts) {
+			break
+		}
+
+		t.Run(fmt.Sprintf("part%d", i+1), func(t *testing.T) {
+			Check(t, parts[i], string(input), want)
+		})
+	}
+}
+
+// InputPath returns location of a private input file with the given
+// extension. Tests run inside package directories, so the path is resolved
+// against the module root.
+func InputPath(t testing.TB, day int, ext string) string {
+	t.Helper()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatalf("go.mod not found")
+		}
+
+		dir = parent
+	}
+
+	return filepath.Join(dir, "input", fmt.Sprintf("day-%02d.%s", day, ext))
+}
+
+func lookup(t testing.TB, day int) []registry.Solver {
+	t.Helper()
+
+	d, ok := registry.Lookup(day)
+	if !ok {
+		t.Fatalf("day %d is not registered", day)
+	}
+
+	return d.Parts
+}
+
+func readAnswers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var answers []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		answers = append(answers, strings.TrimSpace(scanner.Text()))
+	}
+
+	return answers, scanner.Err()
+}
+
+// Benchmark measures every registered part of the day on the private input,
+// and is skipped when the input is absent.
+func Benchmark(b *testing.B, day int) {
+	b.Helper()
+
+	parts := lookup(b, day)
+
+	input, err := os.ReadFile(InputPath(b, day, "txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		b.Skipf("no private input for day %d", day)
+	} else if err != nil {
+		b.Fatalf("read input: %v", err)
+	}
+
+	for i, solve := range parts {
+		b.Run(fmt.Sprintf("part%d", i+1), Bench(solve, input))
+	}
+}
+
+// Bench returns a benchmark of a single solver on the input. It is shared by
+// go test and the aoc runner, so that both measure the same thing.
+func Bench(solve registry.Solver, input []byte) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			if _, err := solve(bytes.NewReader(input)); err != nil {
+				b.Fatalf("solve: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+// BenchResult is a measurement of a single part, as stored in baseline files.
+type BenchResult struct {
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+type benchKey struct {
+	Day  int
+	Part int
+}
+
+func bench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	part := fs.Int("part", 0, "benchmark only the given part (1 or 2)")
+	input := fs.String("input", "", "read input from the given file, or from stdin if \"-\" (default input/day-NN.txt)")
+	benchtime := fs.Duration("benchtime", time.Second, "approximate time to run each part")
+	save := fs.String("save", "", "save results as a baseline to the given file")
+	compare := fs.String("compare", "", "compare results against the baseline in the given file")
+	threshold := fs.Float64("threshold", 0.1, "relative slowdown or growth of allocations reported as a regression")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("bench: expected exactly one day number or \"all\"")
+	}
+
+	days, err := selectDays(positional[0])
+	if err != nil {
+		return fmt.Errorf("bench: %w", err)
+	}
+
+	if *input != "" && len(days) > 1 {
+		return errors.New("bench: --input can only be used with a single day")
+	}
+
+	if err := checkPart(*part, days); err != nil {
+		return fmt.Errorf("bench: %w", err)
+	}
+
+	var baseline map[benchKey]BenchResult
+	if *compare != "" {
+		baseline, err = loadBaseline(*compare)
+		if err != nil {
+			return fmt.Errorf("bench: load baseline: %w", err)
+		}
+	}
+
+	// testing.Benchmark reads its duration from the flags of the testing package
+	testing.Init()
+	if err := flag.Set("test.benchtime", benchtime.String()); err != nil {
+		return fmt.Errorf("bench: set benchtime: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "day\tpart\ttime/op\tallocs/op\tbytes/op\t\t")
+
+	var results []BenchResult
+	regressions, failures := 0, 0
+
+	for _, day := range days {
+		data, err := readInput(day.Number, *input)
+		if err != nil {
+			fmt.Fprintf(w, "%d\t\t\t\t\t\t%v\n", day.Number, err)
+			failures++
+			continue
+		}
+
+		for i, solve := range day.Parts {
+			if *part != 0 && *part != i+1 {
+				continue
+			}
+
+			if _, err := solve(bytes.NewReader(data)); err != nil {
+				fmt.Fprintf(w, "%d\t%d\t\t\t\t\t%v\n", day.Number, i+1, err)
+				failures++
+				continue
+			}
+
+			res := testing.Benchmark(aoctest.Bench(solve, data))
+			result := BenchResult{
+				Day:         day.Number,
+				Part:        i + 1,
+				NsPerOp:     res.NsPerOp(),
+				AllocsPerOp: res.AllocsPerOp(),
+				BytesPerOp:  res.AllocedBytesPerOp(),
+			}
+			results = append(results, result)
+
+			verdict := ""
+			if old, ok := baseline[benchKey{Day: result.Day, Part: result.Part}]; ok {
+				var regressed bool
+				verdict, regressed = compareResults(old, result, *threshold)
+				if regressed {
+					regressions++
+				}
+			}
+
+			fmt.Fprintf(
+				w,
+				"%d\t%d\t%v\t%d\t%d\t\t%s\n",
+				result.Day,
+				result.Part,
+				time.Duration(result.NsPerOp),
+				result.AllocsPerOp,
+				result.BytesPerOp,
+				verdict,
+			)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("bench: %w", err)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("bench: %d days or parts failed", failures)
+	}
+
+	if *save != "" {
+		if err := saveBaseline(*save, results); err != nil {
+			return fmt.Errorf("bench: save baseline: %w", err)
+		}
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("bench: %d parts regressed by more than %.0f%%", regressions, *threshold*100)
+	}
+
+	return nil
+}
+
+// compareResults describes the change from the baseline, and reports whether
+// time or allocations grew by more than the threshold.
+func compareResults(old, res BenchResult, threshold float64) (string, bool) {
+	timeDelta := relativeDelta(old.NsPerOp, res.NsPerOp)
+	allocsDelta := relativeDelta(old.AllocsPerOp, res.AllocsPerOp)
+
+	verdict := fmt.Sprintf("time %+.1f%%, allocs %+.1f%%", timeDelta*100, allocsDelta*100)
+	if timeDelta > threshold || allocsDelta > threshold {
+		return verdict + " REGRESSION", true
+	}
+
+	return verdict, false
+}
+
+func relativeDelta(old, current int64) float64 {
+	if old == 0 {
+		if current == 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	return float64(current-old) / float64(old)
+}
+
+func loadBaseline(path string) (map[benchKey]BenchResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []BenchResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	baseline := make(map[benchKey]BenchResult, len(results))
+	for _, res := range results {
+		baseline[benchKey{Day: res.Day, Part: res.Part}] = res
+	}
+
+	return baseline, nil
+}
+
+func saveBaseline(path string, results []BenchResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
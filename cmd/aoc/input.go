@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// readInput loads puzzle input for the day. An empty path stands for the
+// default location of private inputs, and "-" stands for stdin.
+func readInput(day int, path string) ([]byte, error) {
+	switch path {
+	case "":
+		path = fmt.Sprintf("input/day-%02d.txt", day)
+	case "-":
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
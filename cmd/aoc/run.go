@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "run only the given part (1 or 2)")
+	input := fs.String("input", "", "read input from the given file, or from stdin if \"-\" (default input/day-NN.txt)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("run: expected exactly one day number or \"all\"")
+	}
+
+	days, err := selectDays(positional[0])
+	if err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+
+	if *input != "" && len(days) > 1 {
+		return errors.New("run: --input can only be used with a single day")
+	}
+
+	if err := checkPart(*part, days); err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+
+	failed := false
+	for _, day := range days {
+		data, err := readInput(day.Number, *input)
+		if err != nil {
+			failed = true
+			fmt.Printf("day %d: %v\n", day.Number, err)
+			continue
+		}
+
+		for i, solve := range day.Parts {
+			if *part != 0 && *part != i+1 {
+				continue
+			}
+
+			res, err := solve(bytes.NewReader(data))
+			if err != nil {
+				failed = true
+				fmt.Printf("day %d, part %d: %v\n", day.Number, i+1, err)
+				continue
+			}
+
+			fmt.Printf("day %d, part %d: %v\n", day.Number, i+1, res)
+		}
+	}
+
+	if failed {
+		return errors.New("run: some parts failed")
+	}
+
+	return nil
+}
+
+func selectDays(arg string) ([]registry.Day, error) {
+	if arg == "all" {
+		return registry.All(), nil
+	}
+
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("parse day: %w", err)
+	}
+
+	day, ok := registry.Lookup(number)
+	if !ok {
+		return nil, fmt.Errorf("day %d is not solved", number)
+	}
+
+	return []registry.Day{day}, nil
+}
+
+// checkPart checks that the part is 0, which selects every part, or a part of
+// any of the days. Days without the part skip it, so that e.g. part 2 of all
+// days can be run, although day 25 has only one part.
+func checkPart(part int, days []registry.Day) error {
+	parts := 0
+	for _, day := range days {
+		parts = max(parts, len(day.Parts))
+	}
+
+	if part < 0 || part > parts {
+		return fmt.Errorf("part %d out of range, want 1..%d, or 0 for every part", part, parts)
+	}
+
+	return nil
+}
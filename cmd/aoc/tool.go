@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func tool(args []string) error {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	input := fs.String("input", "", "read input from the given file, or from stdin if \"-\" (default input/day-NN.txt, or stdin for tools reading something else)")
+
+	// flags after the tool name belong to the tool
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("tool: expected a day number")
+	}
+
+	number, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("tool: parse day: %w", err)
+	}
+
+	day, ok := registry.Lookup(number)
+	if !ok {
+		return fmt.Errorf("tool: day %d is not solved", number)
+	}
+
+	if fs.NArg() == 1 {
+		if len(day.Tools) == 0 {
+			fmt.Printf("day %d has no tools\n", day.Number)
+		}
+
+		for _, t := range day.Tools {
+			fmt.Printf("%s\t%s\n", t.Name, t.Description)
+		}
+
+		return nil
+	}
+
+	t, ok := day.LookupTool(fs.Arg(1))
+	if !ok {
+		return fmt.Errorf("tool: day %d has no tool %q", day.Number, fs.Arg(1))
+	}
+
+	path := *input
+	if path == "" && t.Stdin {
+		path = "-"
+	}
+
+	data, err := readInput(day.Number, path)
+	if err != nil {
+		return fmt.Errorf("tool: %w", err)
+	}
+
+	if err := t.Run(fs.Args()[2:], bytes.NewReader(data), os.Stdout); err != nil {
+		return fmt.Errorf("tool %s: %w", t.Name, err)
+	}
+
+	return nil
+}
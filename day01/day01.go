@@ -1,28 +1,20 @@
-package main
+package day01
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"slices"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(1, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	leftList, rightList, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	leftList, rightList, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -44,8 +36,8 @@ func part1() (int, error) {
 	return totalDistance, nil
 }
 
-func part2() (int, error) {
-	leftList, rightList, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	leftList, rightList, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -63,16 +55,10 @@ func part2() (int, error) {
 	return similarityScore, nil
 }
 
-func readInput() ([]int, []int, error) {
-	f, err := os.Open("input/day-01.txt")
-	if err != nil {
-		return nil, nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]int, []int, error) {
 	var leftList, rightList []int
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		var left, right int
 		if _, err := fmt.Sscanf(scanner.Text(), "%d %d", &left, &right); err != nil {
@@ -0,0 +1,28 @@
+package day01
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `3   4
+4   3
+2   5
+1   3
+3   9
+3   3`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 1, []aoctest.Example{
+		{Input: example, Part1: "11", Part2: "31"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 1)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 1)
+}
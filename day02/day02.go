@@ -1,30 +1,22 @@
-package main
+package day02
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(2, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	reports, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	reports, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -39,8 +31,8 @@ func part1() (int, error) {
 	return safeAmount, nil
 }
 
-func part2() (int, error) {
-	reports, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	reports, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -114,16 +106,10 @@ func cutLeftCenterRight(r Report, i int) (Report, Report, Report) {
 	return withoutLeft, withoutCenter, withoutRight
 }
 
-func readInput() ([]Report, error) {
-	f, err := os.Open("input/day-02.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]Report, error) {
 	var reports []Report
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		levels := strings.Split(scanner.Text(), " ")
 
@@ -0,0 +1,28 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `7 6 4 2 1
+1 2 7 8 9
+9 7 6 2 1
+1 3 2 4 5
+8 6 4 4 1
+1 3 6 7 9`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2, []aoctest.Example{
+		{Input: example, Part1: "2", Part2: "4"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 2)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 2)
+}
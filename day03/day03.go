@@ -1,29 +1,20 @@
-package main
+package day03
 
 import (
 	"fmt"
 	"io"
-	"os"
 	"regexp"
 	"strconv"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(3, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	instructions, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	instructions, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -41,8 +32,8 @@ func part1() (int, error) {
 	return sum, nil
 }
 
-func part2() (int, error) {
-	instructions, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	instructions, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -81,14 +72,8 @@ func (m Mul) Evaluate() int {
 
 var mulRegex = regexp.MustCompile(`mul\((\d{1,3}),(\d{1,3})\)|do\(\)|don't\(\)`)
 
-func readInput() ([]any, error) {
-	f, err := os.Open("input/day-03.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
-	memory, err := io.ReadAll(f)
+func readInput(r io.Reader) ([]any, error) {
+	memory, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("read memory: %w", err)
 	}
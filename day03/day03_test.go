@@ -0,0 +1,26 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example1 = `xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))`
+
+const example2 = `xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 3, []aoctest.Example{
+		{Input: example1, Part1: "161"},
+		{Input: example2, Part2: "48"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 3)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 3)
+}
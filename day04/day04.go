@@ -1,27 +1,19 @@
-package main
+package day04
 
 import (
 	"bufio"
 	"fmt"
-	"os"
-)
+	"io"
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(4, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	puzzle, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	puzzle, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -92,8 +84,8 @@ func part1() (int, error) {
 	return wordCount, nil
 }
 
-func part2() (int, error) {
-	puzzle, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	puzzle, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -118,16 +110,10 @@ func part2() (int, error) {
 	return count, nil
 }
 
-func readInput() ([]string, error) {
-	f, err := os.Open("input/day-04.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]string, error) {
 	var lines []string
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
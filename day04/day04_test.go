@@ -0,0 +1,32 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `MMMSXXMASM
+MSAMXMSMSA
+AMXSXMAAMM
+MSAMASMSMX
+XMASAMXAMM
+XXAMMXXAMA
+SMSMSASXSS
+SAXAMASAAA
+MAMMMXMMMM
+MXMXAXMASX`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 4, []aoctest.Example{
+		{Input: example, Part1: "18", Part2: "9"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 4)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 4)
+}
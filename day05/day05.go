@@ -1,29 +1,21 @@
-package main
+package day05
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(5, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	rules, updates, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	rules, updates, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -39,8 +31,8 @@ func part1() (int, error) {
 	return sum, nil
 }
 
-func part2() (int, error) {
-	rules, updates, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	rules, updates, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -125,16 +117,10 @@ func (r PageOrderingRules) Invert() PageOrderingRules {
 	return res
 }
 
-func readInput() (PageOrderingRules, [][]int, error) {
-	f, err := os.Open("input/day-05.txt")
-	if err != nil {
-		return nil, nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) (PageOrderingRules, [][]int, error) {
 	rules := make(PageOrderingRules)
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		text := scanner.Text()
 		if text == "" {
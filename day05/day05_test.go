@@ -0,0 +1,50 @@
+package day05
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `47|53
+97|13
+97|61
+97|47
+75|29
+61|13
+75|53
+29|13
+97|29
+53|29
+61|53
+97|53
+61|29
+47|13
+75|47
+97|75
+47|61
+75|61
+47|29
+75|13
+53|13
+
+75,47,61,53,29
+97,61,53,29,13
+75,29,13
+75,97,47,61,53
+61,13,29
+97,13,75,29,47`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 5, []aoctest.Example{
+		{Input: example, Part1: "143", Part2: "123"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 5)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 5)
+}
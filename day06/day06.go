@@ -0,0 +1,120 @@
+package day06
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(6, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	m, start, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	positions := 0
+
+	for pos := range m.Iterator(Position{Pos: start, Dir: grid.DirectionUp}) {
+		cell := m.At(pos.Pos)
+		if *cell != 'X' {
+			positions++
+		}
+
+		*cell = 'X'
+	}
+
+	return positions, nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	m, start, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	obstacleCount := 0
+
+	visits := make(map[Position]struct{})
+
+	for pos := range m.Iterator(Position{Pos: start, Dir: grid.DirectionUp}) {
+		nextPos := pos.Pos.Add(pos.Dir)
+		if m.InBounds(nextPos) {
+			if next := m.At(nextPos); *next == '.' {
+				m := Map{m.Clone()}
+				*m.At(nextPos) = '#'
+
+				visits := maps.Clone(visits)
+				if hasCycle(m, pos, visits) {
+					obstacleCount++
+				}
+			}
+		}
+
+		*m.At(pos.Pos) = 'X'
+		visits[pos] = struct{}{}
+	}
+
+	return obstacleCount, nil
+}
+
+func hasCycle(m Map, start Position, visits map[Position]struct{}) bool {
+	for pos := range m.Iterator(start) {
+		if _, ok := visits[pos]; ok {
+			return true
+		}
+
+		visits[pos] = struct{}{}
+	}
+
+	return false
+}
+
+type Position struct {
+	Pos grid.Vec2D
+	Dir grid.Dir2D
+}
+
+type Map struct {
+	grid.Grid[byte]
+}
+
+func (m Map) Iterator(position Position) iter.Seq[Position] {
+	return func(yield func(Position) bool) {
+		for {
+			next := position.Pos.Add(position.Dir)
+			for m.InBounds(next) && *m.At(next) == '#' {
+				position.Dir = position.Dir.RotateClockwise()
+				next = position.Pos.Add(position.Dir)
+			}
+
+			if !yield(position) {
+				return
+			}
+
+			if !m.InBounds(next) {
+				return
+			}
+
+			position.Pos = next
+		}
+	}
+}
+
+func readInput(r io.Reader) (Map, grid.Vec2D, error) {
+	g, err := grid.Parse(r)
+	if err != nil {
+		return Map{}, grid.Vec2D{}, fmt.Errorf("parse grid: %w", err)
+	}
+
+	guardPosition, _ := grid.Find(g, '^')
+
+	return Map{g}, guardPosition, nil
+}
@@ -0,0 +1,32 @@
+package day06
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `....#.....
+.........#
+..........
+..#.......
+.......#..
+..........
+.#..^.....
+........#.
+#.........
+......#...`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 6, []aoctest.Example{
+		{Input: example, Part1: "41", Part2: "6"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 6)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 6)
+}
@@ -1,29 +1,21 @@
-package main
+package day07
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(7, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	equations, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	equations, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -39,8 +31,8 @@ func part1() (int, error) {
 	return total, nil
 }
 
-func part2() (int, error) {
-	equations, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	equations, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -109,16 +101,10 @@ func Concatenate(a, b int) int {
 	return a*factor + b
 }
 
-func readInput() ([]Equation, error) {
-	f, err := os.Open("input/day-07.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]Equation, error) {
 	var equations []Equation
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		parts := strings.SplitN(scanner.Text(), ": ", 2)
 
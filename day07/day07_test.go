@@ -0,0 +1,31 @@
+package day07
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `190: 10 19
+3267: 81 40 27
+83: 17 5
+156: 15 6
+7290: 6 8 6 15
+161011: 16 10 13
+192: 17 8 14
+21037: 9 7 18 13
+292: 11 6 16 20`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 7, []aoctest.Example{
+		{Input: example, Part1: "3749", Part2: "11387"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 7)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 7)
+}
@@ -0,0 +1,94 @@
+package day08
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(8, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	m, antennas, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	antinodes := map[grid.Vec2D]struct{}{}
+
+	for _, nodes := range antennas {
+		for i := range nodes {
+			for j := i + 1; j < len(nodes); j++ {
+				distance := nodes[j].To(nodes[i])
+
+				antinode := nodes[i].Add(distance)
+				if m.InBounds(antinode) {
+					antinodes[antinode] = struct{}{}
+				}
+
+				antinode = nodes[j].Sub(distance)
+				if m.InBounds(antinode) {
+					antinodes[antinode] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return len(antinodes), nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	m, antennas, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	antinodes := map[grid.Vec2D]struct{}{}
+
+	for _, nodes := range antennas {
+		for i := range nodes {
+			antinodes[nodes[i]] = struct{}{}
+
+			for j := i + 1; j < len(nodes); j++ {
+				distance := nodes[j].To(nodes[i])
+
+				antinode := nodes[i].Add(distance)
+				for m.InBounds(antinode) {
+					antinodes[antinode] = struct{}{}
+
+					antinode = antinode.Add(distance)
+				}
+
+				antinode = nodes[j].Sub(distance)
+				for m.InBounds(antinode) {
+					antinodes[antinode] = struct{}{}
+
+					antinode = antinode.Sub(distance)
+				}
+			}
+		}
+	}
+
+	return len(antinodes), nil
+}
+
+func readInput(r io.Reader) (grid.Grid[byte], map[byte][]grid.Vec2D, error) {
+	antennasMap, err := grid.Parse(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse grid: %w", err)
+	}
+
+	antennas := map[byte][]grid.Vec2D{}
+
+	for pos, cell := range antennasMap.All() {
+		if cell >= '0' && cell <= '9' || cell >= 'A' && cell <= 'Z' || cell >= 'a' && cell <= 'z' {
+			antennas[cell] = append(antennas[cell], pos)
+		}
+	}
+
+	return antennasMap, antennas, nil
+}
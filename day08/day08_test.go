@@ -0,0 +1,34 @@
+package day08
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `............
+........0...
+.....0......
+.......0....
+....0.......
+......A.....
+............
+............
+........A...
+.........A..
+............
+............`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 8, []aoctest.Example{
+		{Input: example, Part1: "14", Part2: "34"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 8)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 8)
+}
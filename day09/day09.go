@@ -1,27 +1,20 @@
-package main
+package day09
 
 import (
+	"bytes"
 	"container/heap"
 	"fmt"
-	"os"
-)
+	"io"
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(9, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	diskMap, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	diskMap, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -35,8 +28,8 @@ func part1() (int, error) {
 	return checksum, nil
 }
 
-func part2() (int, error) {
-	diskMap, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	diskMap, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -292,15 +285,16 @@ func mapToDisk(diskMap []byte) Disk {
 	}
 }
 
-func readInput() ([]byte, error) {
-	bytes, err := os.ReadFile("input/day-09.txt")
+func readInput(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("os.ReadFile: %w", err)
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
 	}
 
-	for i := range bytes {
-		bytes[i] -= '0'
+	diskMap := bytes.TrimSpace(data)
+	for i := range diskMap {
+		diskMap[i] -= '0'
 	}
 
-	return bytes, err
+	return diskMap, nil
 }
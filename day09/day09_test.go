@@ -0,0 +1,23 @@
+package day09
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `2333133121414131402`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 9, []aoctest.Example{
+		{Input: example, Part1: "1928", Part2: "2858"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 9)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 9)
+}
@@ -0,0 +1,102 @@
+package day10
+
+import (
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/harmlessevil/advent-of-code-2024/graph/search"
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(10, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	topology, trailHeads, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	sum := 0
+	for _, head := range trailHeads {
+		sum += countTrailScore(topology, head)
+	}
+
+	return sum, nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	topology, trailHeads, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	sum := 0
+	for _, head := range trailHeads {
+		sum += countTrailRating(topology, head)
+	}
+
+	return sum, nil
+}
+
+func countTrailScore(topology grid.Grid[byte], start grid.Vec2D) int {
+	_, trails, _ := search.BFS(start, uphill(topology), nil)
+
+	score := 0
+	for pos := range trails.Dist {
+		if topology.Get(pos) == '9' {
+			score++
+		}
+	}
+
+	return score
+}
+
+// countTrailRating counts distinct trails from the start. Every step of a
+// trail climbs exactly one level, so all trails to a summit have the same
+// length, and each of them is one of the shortest paths.
+func countTrailRating(topology grid.Grid[byte], start grid.Vec2D) int {
+	_, trails, _ := search.BFS(start, uphill(topology), nil)
+
+	rating := 0
+	for pos := range trails.Dist {
+		if topology.Get(pos) == '9' {
+			rating += trails.CountPaths(pos)
+		}
+	}
+
+	return rating
+}
+
+func uphill(topology grid.Grid[byte]) func(grid.Vec2D) iter.Seq[grid.Vec2D] {
+	return func(pos grid.Vec2D) iter.Seq[grid.Vec2D] {
+		return func(yield func(grid.Vec2D) bool) {
+			height := topology.Get(pos)
+
+			for next := range topology.Neighbours4(pos) {
+				if topology.Get(next) == height+1 && !yield(next) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func readInput(r io.Reader) (grid.Grid[byte], []grid.Vec2D, error) {
+	topography, err := grid.Parse(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse grid: %w", err)
+	}
+
+	var trailHeads []grid.Vec2D
+	for pos, height := range topography.All() {
+		if height == '0' {
+			trailHeads = append(trailHeads, pos)
+		}
+	}
+
+	return topography, trailHeads, nil
+}
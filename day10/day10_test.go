@@ -0,0 +1,30 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `89010123
+78121874
+87430965
+96549874
+45678903
+32019012
+01329801
+10456732`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 10, []aoctest.Example{
+		{Input: example, Part1: "36", Part2: "81"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 10)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 10)
+}
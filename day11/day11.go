@@ -1,27 +1,19 @@
-package main
+package day11
 
 import (
 	"bytes"
 	"fmt"
-	"os"
-)
+	"io"
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(11, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	stones, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	stones, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -33,8 +25,8 @@ func part1() (int, error) {
 	return len(stones), nil
 }
 
-func part2() (int, error) {
-	stones, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	stones, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -114,13 +106,13 @@ func countDigits(number int) (int, int) {
 	return count, factor
 }
 
-func readInput() ([]int, error) {
-	arrangement, err := os.ReadFile("input/day-11.txt")
+func readInput(r io.Reader) ([]int, error) {
+	arrangement, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("os.ReadFile: %w", err)
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
 	}
 
-	numbers := bytes.Split(arrangement, []byte{' '})
+	numbers := bytes.Split(bytes.TrimSpace(arrangement), []byte{' '})
 
 	stones := make([]int, len(numbers))
 	for i, number := range numbers {
@@ -0,0 +1,23 @@
+package day11
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `125 17`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 11, []aoctest.Example{
+		{Input: example, Part1: "55312"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 11)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 11)
+}
@@ -0,0 +1,255 @@
+package day12
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(12, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	m, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	polygons := computePolygons(m)
+
+	total := 0
+
+	set := map[*Polygon]struct{}{}
+	for _, polygon := range polygons {
+		if _, ok := set[polygon]; ok {
+			continue
+		}
+
+		set[polygon] = struct{}{}
+
+		total += polygon.Area * polygon.Perimeter
+	}
+
+	return total, nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	m, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	polygons := computePolygons(m)
+
+	total := 0
+
+	set := map[*Polygon]struct{}{}
+	for _, polygon := range polygons {
+		if _, ok := set[polygon]; ok {
+			continue
+		}
+
+		set[polygon] = struct{}{}
+
+		price := polygon.Area * polygon.Sides
+
+		total += price
+	}
+
+	return total, nil
+}
+
+func computePolygons(m grid.Grid[byte]) map[grid.Vec2D]*Polygon {
+	polygons := map[grid.Vec2D]*Polygon{}
+
+	for row := 0; row < m.Rows(); row++ {
+		for col := 0; col < m.Cols(); col++ {
+			start := grid.Vec2D{Row: row, Col: col}
+			if polygons[start] == nil {
+				polygons[start] = new(Polygon)
+				findExtents(m, polygons, start)
+			}
+		}
+	}
+
+	return polygons
+}
+
+func findExtents(m grid.Grid[byte], polygons map[grid.Vec2D]*Polygon, start grid.Vec2D) {
+	plant := m.Get(start)
+	polygon := polygons[start]
+
+	neighbours := 0
+	horizontalNeighbours := 0
+	verticalNeighbours := 0
+
+	for _, dir := range []grid.Dir2D{grid.DirectionRight, grid.DirectionDown, grid.DirectionLeft, grid.DirectionUp} {
+		next := start.Add(dir)
+
+		if !m.InBounds(next) || m.Get(next) != plant || polygons[next] == nil {
+			continue
+		}
+
+		neighbours++
+		if dir.IsHorizontal() {
+			horizontalNeighbours++
+		} else {
+			verticalNeighbours++
+		}
+	}
+
+	polygon.Area++
+
+	switch neighbours {
+	case 0:
+		polygon.Perimeter += 4
+		polygon.Sides += 4
+	case 1:
+		polygon.Perimeter += 2
+
+		hasHorizontalNeighbour := horizontalNeighbours == 1
+
+		var other grid.Vec2D
+		if hasHorizontalNeighbour {
+			other = start.Add(grid.DirectionRight)
+			if !m.InBounds(other) || m.Get(other) != plant || polygons[other] == nil {
+				other = start.Add(grid.DirectionLeft)
+			}
+		} else {
+			other = start.Add(grid.DirectionDown)
+			if !m.InBounds(other) || m.Get(other) != plant || polygons[other] == nil {
+				other = start.Add(grid.DirectionUp)
+			}
+		}
+
+		var directions []grid.Dir2D
+		if hasHorizontalNeighbour {
+			directions = []grid.Dir2D{grid.DirectionDown, grid.DirectionUp}
+		} else {
+			directions = []grid.Dir2D{grid.DirectionRight, grid.DirectionLeft}
+		}
+
+		diagonalNeighbours := 0
+		for _, dir := range directions {
+			next := other.Add(dir)
+
+			if !m.InBounds(next) || m.Get(next) != plant || polygons[next] == nil {
+				continue
+			}
+
+			diagonalNeighbours++
+		}
+
+		switch diagonalNeighbours {
+		case 1:
+			polygon.Sides += 2
+		case 2:
+			polygon.Sides += 4
+		}
+	case 2:
+		var directions []grid.Dir2D
+		var base int // how many sides add or remove from polygon if this cell has 0 diagonal neighbours?
+
+		if horizontalNeighbours == verticalNeighbours {
+			if h := start.Add(grid.DirectionRight); !m.InBounds(h) || m.Get(h) != plant || polygons[h] == nil {
+				directions = append(directions, grid.DirectionLeft)
+			} else {
+				directions = append(directions, grid.DirectionRight)
+			}
+
+			if v := start.Add(grid.DirectionDown); !m.InBounds(v) || m.Get(v) != plant || polygons[v] == nil {
+				directions = append(directions, grid.DirectionUp)
+			} else {
+				directions = append(directions, grid.DirectionDown)
+			}
+
+			directions = []grid.Dir2D{
+				directions[0].Add(directions[1].Neg()),
+				directions[1].Add(directions[0].Neg()),
+			}
+
+			base = -2
+		} else {
+			directions = []grid.Dir2D{grid.DirectionRightDown, grid.DirectionLeftDown, grid.DirectionLeftUp, grid.DirectionRightUp}
+
+			base = -4
+		}
+
+		diagonalNeighbours := 0
+		for _, dir := range directions {
+			next := start.Add(dir)
+
+			if !m.InBounds(next) || m.Get(next) != plant || polygons[next] == nil {
+				continue
+			}
+
+			diagonalNeighbours++
+		}
+
+		polygon.Sides += base + diagonalNeighbours*2
+	case 3:
+		polygon.Perimeter -= 2
+
+		hasHorizontalFreeSpace := horizontalNeighbours < verticalNeighbours
+
+		var free grid.Vec2D
+		if hasHorizontalFreeSpace {
+			free = start.Add(grid.DirectionRight)
+			if m.InBounds(free) && m.Get(free) == plant && polygons[free] != nil {
+				free = start.Add(grid.DirectionLeft)
+			}
+		} else {
+			free = start.Add(grid.DirectionDown)
+			if m.InBounds(free) && m.Get(free) == plant && polygons[free] != nil {
+				free = start.Add(grid.DirectionUp)
+			}
+		}
+
+		var directions []grid.Dir2D
+		if hasHorizontalFreeSpace {
+			directions = []grid.Dir2D{grid.DirectionDown, grid.DirectionUp}
+		} else {
+			directions = []grid.Dir2D{grid.DirectionRight, grid.DirectionLeft}
+		}
+
+		diagonalNeighbours := 0
+		for _, dir := range directions {
+			next := free.Add(dir)
+
+			if !m.InBounds(next) || m.Get(next) != plant || polygons[next] == nil {
+				continue
+			}
+
+			diagonalNeighbours++
+		}
+
+		polygon.Sides += -4 + diagonalNeighbours*2
+	case 4:
+		polygon.Perimeter -= 4
+		polygon.Sides -= 4
+	}
+
+	for _, dir := range []grid.Dir2D{grid.DirectionRight, grid.DirectionDown, grid.DirectionLeft, grid.DirectionUp} {
+		next := start.Add(dir)
+
+		if !m.InBounds(next) || m.Get(next) != plant || polygons[next] != nil {
+			continue
+		}
+
+		polygons[next] = polygon
+		findExtents(m, polygons, next)
+	}
+}
+
+type Polygon struct {
+	Area      int
+	Perimeter int
+	Sides     int
+}
+
+func readInput(r io.Reader) (grid.Grid[byte], error) {
+	return grid.Parse(r)
+}
@@ -0,0 +1,45 @@
+package day12
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const small = `AAAA
+BBCD
+BBCC
+EEEC`
+
+const nested = `OOOOO
+OXOXO
+OOOOO
+OXOXO
+OOOOO`
+
+const large = `RRRRIICCFF
+RRRRIICCCF
+VVRRRCCFFF
+VVRCCCJFFF
+VVVVCJJCFE
+VVIVCCJJEE
+VVIIICJJEE
+MIIIIIJJEE
+MIIISIJEEE
+MMMISSJEEE`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 12, []aoctest.Example{
+		{Name: "small", Input: small, Part1: "140", Part2: "80"},
+		{Name: "nested", Input: nested, Part1: "772", Part2: "436"},
+		{Name: "large", Input: large, Part1: "1930", Part2: "1206"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 12)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 12)
+}
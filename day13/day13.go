@@ -1,27 +1,19 @@
-package main
+package day13
 
 import (
 	"bufio"
 	"fmt"
-	"os"
-)
+	"io"
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(13, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	machines, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	machines, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -37,8 +29,8 @@ func part1() (int, error) {
 	return tokens, nil
 }
 
-func part2() (int, error) {
-	machines, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	machines, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -93,16 +85,10 @@ type Machine struct {
 	Prize Vec2D
 }
 
-func readInput() ([]Machine, error) {
-	f, err := os.Open("input/day-13.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]Machine, error) {
 	var machines []Machine
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		var m Machine
 
@@ -1,38 +1,36 @@
-package main
+package day14
 
 import (
 	"bufio"
 	"cmp"
 	"fmt"
-	"os"
+	"io"
 	"slices"
+
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
 )
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(14, registry.Part(Part1), registry.Part(Part2))
+}
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+var bathroom = grid.Vec2D{
+	Row: 103,
+	Col: 101,
 }
 
-func part1() (int, error) {
-	robots, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	return part1(r, bathroom)
+}
+
+func part1(r io.Reader, boundaries grid.Vec2D) (int, error) {
+	robots, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
 
 	const seconds = 100
-	boundaries := Vec2D{
-		Row: 103,
-		Col: 101,
-	}
 
 	for i := range robots {
 		robots[i] = move(robots[i], seconds, boundaries)
@@ -42,9 +40,9 @@ func part1() (int, error) {
 	return score, nil
 }
 
-func computeSafetyScore(robots []Robot, boundaries Vec2D) int {
+func computeSafetyScore(robots []Robot, boundaries grid.Vec2D) int {
 	quadrants := [4]int{}
-	quadrantSizes := Vec2D{
+	quadrantSizes := grid.Vec2D{
 		Row: boundaries.Row / 2,
 		Col: boundaries.Col / 2,
 	}
@@ -68,16 +66,13 @@ func computeSafetyScore(robots []Robot, boundaries Vec2D) int {
 	return quadrants[0] * quadrants[1] * quadrants[2] * quadrants[3]
 }
 
-func part2() (int, error) {
-	robots, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	robots, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
 
-	boundaries := Vec2D{
-		Row: 103,
-		Col: 101,
-	}
+	boundaries := bathroom
 
 	states := make([]State, boundaries.Row*boundaries.Col)
 	states[0] = State{
@@ -112,9 +107,9 @@ type State struct {
 	Seconds     int
 }
 
-func move(robot Robot, seconds int, boundaries Vec2D) Robot {
+func move(robot Robot, seconds int, boundaries grid.Vec2D) Robot {
 	return Robot{
-		Position: Vec2D{
+		Position: grid.Vec2D{
 			Row: ((robot.Velocity.Row*seconds+robot.Position.Row)%boundaries.Row + boundaries.Row) % boundaries.Row,
 			Col: ((robot.Velocity.Col*seconds+robot.Position.Col)%boundaries.Col + boundaries.Col) % boundaries.Col,
 		},
@@ -122,17 +117,16 @@ func move(robot Robot, seconds int, boundaries Vec2D) Robot {
 	}
 }
 
-func printMap(robots []Robot, boundaries Vec2D) {
-	rows := make([][]byte, boundaries.Row)
-	for i := range rows {
-		rows[i] = make([]byte, boundaries.Col)
-		for j := range rows[i] {
-			rows[i][j] = '.'
+func printMap(robots []Robot, boundaries grid.Vec2D) {
+	rows := grid.New[byte](boundaries.Row, boundaries.Col)
+	for _, row := range rows {
+		for j := range row {
+			row[j] = '.'
 		}
 	}
 
 	for _, robot := range robots {
-		cell := &rows[robot.Position.Row][robot.Position.Col]
+		cell := rows.At(robot.Position)
 		if *cell == '.' {
 			*cell = '1'
 		} else {
@@ -145,26 +139,15 @@ func printMap(robots []Robot, boundaries Vec2D) {
 	}
 }
 
-type Vec2D struct {
-	Row int
-	Col int
-}
-
 type Robot struct {
-	Position Vec2D
-	Velocity Vec2D
+	Position grid.Vec2D
+	Velocity grid.Vec2D
 }
 
-func readInput() ([]Robot, error) {
-	f, err := os.Open("input/day-14.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]Robot, error) {
 	var robots []Robot
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		var robot Robot
 
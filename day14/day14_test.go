@@ -0,0 +1,40 @@
+package day14
+
+import (
+	"io"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+const example = `p=0,4 v=3,-3
+p=6,3 v=-1,-3
+p=10,3 v=-1,2
+p=2,0 v=2,-1
+p=0,0 v=1,3
+p=3,0 v=-2,-2
+p=7,6 v=-1,-3
+p=3,0 v=-1,-2
+p=9,3 v=2,3
+p=7,3 v=-1,2
+p=2,4 v=2,-3
+p=9,5 v=-3,-3`
+
+func TestExamples(t *testing.T) {
+	// The example takes place in a smaller bathroom than the real one.
+	part1 := registry.Part(func(r io.Reader) (int, error) {
+		return part1(r, grid.Vec2D{Row: 7, Col: 11})
+	})
+
+	aoctest.Check(t, part1, example, "12")
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 14)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 14)
+}
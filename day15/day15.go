@@ -1,29 +1,21 @@
-package main
+package day15
 
 import (
 	"bufio"
-	"bytes"
 	"fmt"
-	"os"
+	"io"
 	"slices"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(15, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	m, robot, movements, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	m, robot, movements, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -44,8 +36,8 @@ func part1() (int, error) {
 	return total, nil
 }
 
-func part2() (int, error) {
-	m, _, movements, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	m, _, movements, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -68,19 +60,11 @@ func part2() (int, error) {
 	return total, nil
 }
 
-type WideMap Map
-
-func (m WideMap) At(v Vec2D) *byte {
-	return &m[v.Row][v.Col]
-}
-
-func mapToWideMap(m Map) (WideMap, Vec2D) {
-	var robot Vec2D
+func mapToWideMap(m grid.Grid[byte]) (grid.Grid[byte], grid.Vec2D) {
+	var robot grid.Vec2D
 
-	res := make(WideMap, len(m))
+	res := grid.New[byte](m.Rows(), m.Cols()*2)
 	for row, line := range m {
-		res[row] = make([]byte, len(line)*2)
-
 		for col, cell := range line {
 			switch cell {
 			case '#':
@@ -96,7 +80,7 @@ func mapToWideMap(m Map) (WideMap, Vec2D) {
 				res[row][col*2] = '@'
 				res[row][col*2+1] = '.'
 
-				robot = Vec2D{Row: row, Col: col * 2}
+				robot = grid.Vec2D{Row: row, Col: col * 2}
 			}
 		}
 	}
@@ -104,7 +88,7 @@ func mapToWideMap(m Map) (WideMap, Vec2D) {
 	return res, robot
 }
 
-func moveRobot(m Map, robot Vec2D, movement Dir2D) Vec2D {
+func moveRobot(m grid.Grid[byte], robot grid.Vec2D, movement grid.Dir2D) grid.Vec2D {
 	next := robot.Add(movement)
 	for cell := m.At(next); *cell == 'O'; cell = m.At(next) {
 		next = next.Add(movement)
@@ -125,7 +109,7 @@ func moveRobot(m Map, robot Vec2D, movement Dir2D) Vec2D {
 	return nextBox
 }
 
-func moveRobotOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D {
+func moveRobotOnWideMap(m grid.Grid[byte], robot grid.Vec2D, movement grid.Dir2D) grid.Vec2D {
 	if movement.IsVertical() {
 		return moveRobotVerticallyOnWideMap(m, robot, movement)
 	}
@@ -133,7 +117,7 @@ func moveRobotOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D {
 	return moveRobotHorizontallyOnWideMap(m, robot, movement)
 }
 
-func moveRobotHorizontallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D {
+func moveRobotHorizontallyOnWideMap(m grid.Grid[byte], robot grid.Vec2D, movement grid.Dir2D) grid.Vec2D {
 	next := robot.Add(movement)
 	for cell := m.At(next); *cell == '[' || *cell == ']'; cell = m.At(next) {
 		next = next.Add(movement).Add(movement)
@@ -148,7 +132,7 @@ func moveRobotHorizontallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2
 		line := m[robot.Row]
 
 		var dst, src []byte
-		if movement == DirectionLeft {
+		if movement == grid.DirectionLeft {
 			dst = line[next.Col:nextBox.Col]
 			src = line[next.Col+1 : nextBox.Col+1]
 		} else {
@@ -165,12 +149,12 @@ func moveRobotHorizontallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2
 	return nextBox
 }
 
-func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D {
-	var boxes []Vec2D
+func moveRobotVerticallyOnWideMap(m grid.Grid[byte], robot grid.Vec2D, movement grid.Dir2D) grid.Vec2D {
+	var boxes []grid.Vec2D
 
-	visited := map[Vec2D]struct{}{}
+	visited := map[grid.Vec2D]struct{}{}
 
-	front := []Vec2D{robot}
+	front := []grid.Vec2D{robot}
 	for len(front) > 0 {
 		pos := front[0]
 		front = front[1:]
@@ -180,7 +164,7 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 		next := pos.Add(movement)
 		cell := *m.At(next)
 
-		if cell == '#' || currentCell != '@' && *m.At(next.Add(DirectionRight)) == '#' {
+		if cell == '#' || currentCell != '@' && *m.At(next.Add(grid.DirectionRight)) == '#' {
 			return robot
 		}
 
@@ -190,7 +174,7 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 
 		if currentCell == '@' || cell == currentCell {
 			if cell == ']' {
-				next = next.Add(DirectionLeft)
+				next = next.Add(grid.DirectionLeft)
 			}
 
 			if _, ok := visited[next]; !ok {
@@ -200,7 +184,7 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 				boxes = append(boxes, next)
 			}
 		} else {
-			left := next.Add(DirectionLeft)
+			left := next.Add(grid.DirectionLeft)
 			if _, ok := visited[left]; !ok && *m.At(left) == '[' {
 				visited[left] = struct{}{}
 
@@ -208,7 +192,7 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 				boxes = append(boxes, left)
 			}
 
-			right := next.Add(DirectionRight)
+			right := next.Add(grid.DirectionRight)
 			if _, ok := visited[right]; !ok && *m.At(right) == '[' {
 				visited[right] = struct{}{}
 
@@ -221,11 +205,11 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 	slices.Reverse(boxes)
 	for _, box := range boxes {
 		*m.At(box) = '.'
-		*m.At(box.Add(DirectionRight)) = '.'
+		*m.At(box.Add(grid.DirectionRight)) = '.'
 
 		next := box.Add(movement)
 		*m.At(next) = '['
-		*m.At(next.Add(DirectionRight)) = ']'
+		*m.At(next.Add(grid.DirectionRight)) = ']'
 	}
 
 	next := robot.Add(movement)
@@ -235,73 +219,31 @@ func moveRobotVerticallyOnWideMap(m WideMap, robot Vec2D, movement Dir2D) Vec2D
 	return next
 }
 
-type Map [][]byte
-
-func (m Map) At(v Vec2D) *byte {
-	return &m[v.Row][v.Col]
-}
-
-type Vec2D struct {
-	Row int
-	Col int
-}
-
-func (v Vec2D) Add(d Dir2D) Vec2D {
-	return Vec2D{Row: v.Row + d.Row, Col: v.Col + d.Col}
-}
+func readInput(r io.Reader) (grid.Grid[byte], grid.Vec2D, []grid.Dir2D, error) {
+	scanner := bufio.NewScanner(r)
 
-type Dir2D Vec2D
-
-var (
-	DirectionUp    = Dir2D{Row: -1, Col: 0}
-	DirectionDown  = Dir2D{Row: 1, Col: 0}
-	DirectionLeft  = Dir2D{Row: 0, Col: -1}
-	DirectionRight = Dir2D{Row: 0, Col: 1}
-)
-
-func (d Dir2D) IsVertical() bool {
-	return d == DirectionUp || d == DirectionDown
-}
-
-func readInput() (Map, Vec2D, []Dir2D, error) {
-	f, err := os.Open("input/day-15.txt")
+	m, err := grid.Scan(scanner)
 	if err != nil {
-		return nil, Vec2D{}, nil, fmt.Errorf("open file: %w", err)
+		return nil, grid.Vec2D{}, nil, err
 	}
-	defer f.Close()
-
-	var m Map
-	var robot Vec2D
 
-	scanner := bufio.NewScanner(f)
-	for row := 0; scanner.Scan(); row++ {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			break
-		}
-
-		if col := bytes.IndexByte(line, '@'); col >= 0 {
-			robot = Vec2D{Row: row, Col: col}
-		}
-
-		m = append(m, slices.Clone(line))
-	}
+	robot, _ := grid.Find(m, '@')
 
-	var movements []Dir2D
+	var movements []grid.Dir2D
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		slices.Grow(movements, len(line))
+		movements = slices.Grow(movements, len(line))
 
 		for _, b := range line {
 			switch b {
 			case '^':
-				movements = append(movements, DirectionUp)
+				movements = append(movements, grid.DirectionUp)
 			case 'v':
-				movements = append(movements, DirectionDown)
+				movements = append(movements, grid.DirectionDown)
 			case '<':
-				movements = append(movements, DirectionLeft)
+				movements = append(movements, grid.DirectionLeft)
 			case '>':
-				movements = append(movements, DirectionRight)
+				movements = append(movements, grid.DirectionRight)
 			}
 		}
 	}
@@ -0,0 +1,97 @@
+package day16
+
+import (
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/harmlessevil/advent-of-code-2024/graph/search"
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(16, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	m, start, end, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	score, _ := dijkstra(m, Reindeer{Pos: start, Dir: grid.DirectionRight}, end)
+
+	return score, nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	m, start, end, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	_, tiles := dijkstra(m, Reindeer{Pos: start, Dir: grid.DirectionRight}, end)
+
+	return len(tiles), nil
+}
+
+// dijkstra finds the lowest score to reach the end, and all tiles that lie on
+// any of the paths with that score. To account for turns, the reindeer moves
+// in 3D space, where the third dimension is its direction.
+func dijkstra(m grid.Grid[byte], start Reindeer, end grid.Vec2D) (int, map[grid.Vec2D]struct{}) {
+	moves := func(from Reindeer) iter.Seq2[Reindeer, int] {
+		return func(yield func(Reindeer, int) bool) {
+			dir := from.Dir
+
+			for _, next := range []struct {
+				Dir   grid.Dir2D
+				Score int
+			}{
+				{Dir: dir, Score: 1},
+				{Dir: dir.RotateClockwise(), Score: 1_001},
+				{Dir: dir.RotateCounterclockwise(), Score: 1_001},
+			} {
+				pos := from.Pos.Add(next.Dir)
+				if m.Get(pos) == '#' {
+					continue
+				}
+
+				if !yield(Reindeer{Pos: pos, Dir: next.Dir}, next.Score) {
+					return
+				}
+			}
+		}
+	}
+
+	reindeer, paths, ok := search.Dijkstra(start, moves, func(r Reindeer) bool {
+		return r.Pos == end
+	})
+	if !ok {
+		panic("unreachable")
+	}
+
+	tiles := map[grid.Vec2D]struct{}{}
+	for r := range paths.Nodes(reindeer) {
+		tiles[r.Pos] = struct{}{}
+	}
+
+	return paths.Dist[reindeer], tiles
+}
+
+type Reindeer struct {
+	Pos grid.Vec2D
+	Dir grid.Dir2D
+}
+
+func readInput(r io.Reader) (grid.Grid[byte], grid.Vec2D, grid.Vec2D, error) {
+	m, err := grid.Parse(r)
+	if err != nil {
+		return nil, grid.Vec2D{}, grid.Vec2D{}, fmt.Errorf("parse grid: %w", err)
+	}
+
+	start, _ := grid.Find(m, 'S')
+	end, _ := grid.Find(m, 'E')
+
+	return m, start, end, nil
+}
@@ -0,0 +1,56 @@
+package day16
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example1 = `###############
+#.......#....E#
+#.#.###.#.###.#
+#.....#.#...#.#
+#.###.#####.#.#
+#.#.#.......#.#
+#.#.#####.###.#
+#...........#.#
+###.#.#####.#.#
+#...#.....#.#.#
+#.#.#.###.#.#.#
+#.....#...#.#.#
+#.###.#.#.#.#.#
+#S..#.....#...#
+###############`
+
+const example2 = `#################
+#...#...#...#..E#
+#.#.#.#.#.#.#.#.#
+#.#.#.#...#...#.#
+#.#.#.#.###.#.#.#
+#...#.#.#.....#.#
+#.#.#.#.#.#####.#
+#.#...#.#.#.....#
+#.#.#####.#.###.#
+#.#.#.......#...#
+#.#.###.#####.###
+#.#.#...#.....#.#
+#.#.#.#####.###.#
+#.#.#.........#.#
+#.#.#.#########.#
+#S#.............#
+#################`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 16, []aoctest.Example{
+		{Input: example1, Part1: "7036", Part2: "45"},
+		{Input: example2, Part1: "11048", Part2: "64"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 16)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 16)
+}
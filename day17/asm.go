@@ -0,0 +1,190 @@
+package day17
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(17, registry.Tool{
+		Name:        "disasm",
+		Description: "print the program as an assembly listing",
+		Run:         disasmTool,
+	})
+
+	registry.RegisterTool(17, registry.Tool{
+		Name:        "asm",
+		Description: "assemble a listing read from stdin, or from --input, into a program",
+		Run:         asmTool,
+		Stdin:       true,
+	})
+}
+
+// Mnemonic describes how an opcode is written in assembly listings.
+type Mnemonic struct {
+	Name string
+
+	// Combo is set for instructions that take a combo operand. Combo operands
+	// referring to registers are written as register names.
+	Combo bool
+}
+
+// Mnemonics maps opcodes to their mnemonics, and serves as an assembler and
+// a disassembler for the instruction set they describe.
+type Mnemonics []Mnemonic
+
+// DefaultMnemonics describe DefaultInstructionSet.
+var DefaultMnemonics = Mnemonics{
+	{Name: "adv", Combo: true},
+	{Name: "bxl"},
+	{Name: "bst", Combo: true},
+	{Name: "jnz"},
+	{Name: "bxc"},
+	{Name: "out", Combo: true},
+	{Name: "bdv", Combo: true},
+	{Name: "cdv", Combo: true},
+}
+
+var registerNames = map[int]string{4: "A", 5: "B", 6: "C"}
+
+// Disassemble turns the program into a listing with an instruction per line,
+// e.g. "bst A". Every operand is kept, even if the instruction ignores it, so
+// that the listing assembles back into the same program. The reserved combo
+// operand 7 is written as a number.
+func (ms Mnemonics) Disassemble(program []int) (string, error) {
+	var sb strings.Builder
+
+	for ip := 0; ip < len(program); ip += 2 {
+		if ip+1 >= len(program) {
+			return "", fmt.Errorf("instruction at %d has no operand", ip)
+		}
+
+		opcode, operand := program[ip], program[ip+1]
+		if opcode < 0 || opcode >= len(ms) {
+			return "", fmt.Errorf("unknown opcode %d at %d", opcode, ip)
+		}
+
+		mnemonic := ms[opcode]
+
+		arg := strconv.Itoa(operand)
+		if name, ok := registerNames[operand]; ok && mnemonic.Combo {
+			arg = name
+		}
+
+		fmt.Fprintf(&sb, "%s %s\n", mnemonic.Name, arg)
+	}
+
+	return sb.String(), nil
+}
+
+// Assemble parses a listing produced by Disassemble. Blank lines and comments
+// starting with ";" are ignored.
+func (ms Mnemonics) Assemble(listing string) ([]int, error) {
+	opcodes := make(map[string]int, len(ms))
+	for opcode, mnemonic := range ms {
+		opcodes[mnemonic.Name] = opcode
+	}
+
+	var program []int
+
+	for i, line := range strings.Split(listing, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected mnemonic and operand, got %q", i+1, line)
+		}
+
+		opcode, ok := opcodes[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown mnemonic %q", i+1, fields[0])
+		}
+
+		operand, err := ms.parseOperand(ms[opcode], fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		program = append(program, opcode, operand)
+	}
+
+	return program, nil
+}
+
+func (ms Mnemonics) parseOperand(mnemonic Mnemonic, arg string) (int, error) {
+	if mnemonic.Combo {
+		for operand, name := range registerNames {
+			if arg == name {
+				return operand, nil
+			}
+		}
+	}
+
+	operand, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid operand %q of %s", arg, mnemonic.Name)
+	}
+
+	if operand < 0 || operand > 7 {
+		return 0, fmt.Errorf("operand %d of %s is not a 3-bit number", operand, mnemonic.Name)
+	}
+
+	return operand, nil
+}
+
+func disasmTool(args []string, in io.Reader, out io.Writer) error {
+	if err := flag.NewFlagSet("disasm", flag.ContinueOnError).Parse(args); err != nil {
+		return err
+	}
+
+	vm, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	listing, err := DefaultMnemonics.Disassemble(vm.Program)
+	if err != nil {
+		return fmt.Errorf("disassemble: %w", err)
+	}
+
+	_, err = io.WriteString(out, listing)
+	return err
+}
+
+func asmTool(args []string, in io.Reader, out io.Writer) error {
+	if err := flag.NewFlagSet("asm", flag.ContinueOnError).Parse(args); err != nil {
+		return err
+	}
+
+	listing, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	program, err := DefaultMnemonics.Assemble(string(listing))
+	if err != nil {
+		return fmt.Errorf("assemble: %w", err)
+	}
+
+	if len(program) == 0 {
+		return errors.New("assemble: listing is empty")
+	}
+
+	numbers := make([]string, len(program))
+	for i, num := range program {
+		numbers[i] = strconv.Itoa(num)
+	}
+
+	_, err = fmt.Fprintf(out, "Program: %s\n", strings.Join(numbers, ","))
+	return err
+}
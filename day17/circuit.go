@@ -0,0 +1,338 @@
+package day17
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bit is a boolean formula over bits of the initial value of register A. Bits
+// are stored in a Circuit, which shares equal subformulas.
+type Bit int32
+
+// Constant bits, present in every circuit.
+const (
+	False Bit = iota
+	True
+)
+
+type gateKind uint8
+
+const (
+	gateConst gateKind = iota
+	gateVar
+	gateNot
+	gateAnd
+	gateOr
+	gateXor
+	gateIte
+)
+
+type gate struct {
+	kind    gateKind
+	a, b, c Bit
+
+	// variable is the bit of A, which a variable refers to
+	variable int
+}
+
+// Circuit holds formulas built from variables, which are bits of register A,
+// and simplifies them as they are built.
+type Circuit struct {
+	gates []gate
+	index map[gate]Bit
+}
+
+func NewCircuit() *Circuit {
+	c := &Circuit{index: map[gate]Bit{}}
+
+	c.add(gate{kind: gateConst})
+	c.add(gate{kind: gateConst, variable: 1})
+
+	return c
+}
+
+func (c *Circuit) add(g gate) Bit {
+	if b, ok := c.index[g]; ok {
+		return b
+	}
+
+	b := Bit(len(c.gates))
+	c.gates = append(c.gates, g)
+	c.index[g] = b
+
+	return b
+}
+
+// Const returns True or False.
+func Const(v bool) Bit {
+	if v {
+		return True
+	}
+
+	return False
+}
+
+// Var returns the i-th bit of A, counting from the least significant one.
+func (c *Circuit) Var(i int) Bit {
+	return c.add(gate{kind: gateVar, variable: i})
+}
+
+func (c *Circuit) Not(a Bit) Bit {
+	switch {
+	case a == False:
+		return True
+	case a == True:
+		return False
+	case c.gates[a].kind == gateNot:
+		return c.gates[a].a
+	}
+
+	return c.add(gate{kind: gateNot, a: a})
+}
+
+func (c *Circuit) And(a, b Bit) Bit {
+	switch {
+	case a == False || b == False:
+		return False
+	case a == True:
+		return b
+	case b == True || a == b:
+		return a
+	case c.complementary(a, b):
+		return False
+	}
+
+	return c.add(gate{kind: gateAnd, a: min(a, b), b: max(a, b)})
+}
+
+func (c *Circuit) Or(a, b Bit) Bit {
+	switch {
+	case a == True || b == True:
+		return True
+	case a == False:
+		return b
+	case b == False || a == b:
+		return a
+	case c.complementary(a, b):
+		return True
+	}
+
+	return c.add(gate{kind: gateOr, a: min(a, b), b: max(a, b)})
+}
+
+func (c *Circuit) Xor(a, b Bit) Bit {
+	switch {
+	case a == False:
+		return b
+	case b == False:
+		return a
+	case a == True:
+		return c.Not(b)
+	case b == True:
+		return c.Not(a)
+	case a == b:
+		return False
+	case c.complementary(a, b):
+		return True
+	}
+
+	return c.add(gate{kind: gateXor, a: min(a, b), b: max(a, b)})
+}
+
+// Ite returns then if cond holds, and otherwise els.
+func (c *Circuit) Ite(cond, then, els Bit) Bit {
+	switch {
+	case cond == True || then == els:
+		return then
+	case cond == False:
+		return els
+	case then == True && els == False:
+		return cond
+	case then == False && els == True:
+		return c.Not(cond)
+	}
+
+	return c.add(gate{kind: gateIte, a: cond, b: then, c: els})
+}
+
+func (c *Circuit) complementary(a, b Bit) bool {
+	return c.gates[a].kind == gateNot && c.gates[a].a == b ||
+		c.gates[b].kind == gateNot && c.gates[b].a == a
+}
+
+// Eval returns value of the formula for the given value of A.
+func (c *Circuit) Eval(b Bit, a int) bool {
+	g := c.gates[b]
+
+	switch g.kind {
+	case gateConst:
+		return g.variable == 1
+	case gateVar:
+		return g.variable < 63 && a>>g.variable&1 == 1
+	case gateNot:
+		return !c.Eval(g.a, a)
+	case gateAnd:
+		return c.Eval(g.a, a) && c.Eval(g.b, a)
+	case gateOr:
+		return c.Eval(g.a, a) || c.Eval(g.b, a)
+	case gateXor:
+		return c.Eval(g.a, a) != c.Eval(g.b, a)
+	case gateIte:
+		if c.Eval(g.a, a) {
+			return c.Eval(g.b, a)
+		}
+
+		return c.Eval(g.c, a)
+	}
+
+	panic(fmt.Errorf("unknown gate %d", g.kind))
+}
+
+// Format writes the formula, e.g. "(a3 ^ !a5)", where a3 is the fourth bit
+// of A. Shared subformulas are repeated.
+func (c *Circuit) Format(b Bit) string {
+	var sb strings.Builder
+	c.format(&sb, b)
+
+	return sb.String()
+}
+
+func (c *Circuit) format(sb *strings.Builder, b Bit) {
+	g := c.gates[b]
+
+	binary := func(op string) {
+		sb.WriteByte('(')
+		c.format(sb, g.a)
+		sb.WriteString(op)
+		c.format(sb, g.b)
+		sb.WriteByte(')')
+	}
+
+	switch g.kind {
+	case gateConst:
+		fmt.Fprint(sb, g.variable)
+	case gateVar:
+		fmt.Fprintf(sb, "a%d", g.variable)
+	case gateNot:
+		sb.WriteByte('!')
+		c.format(sb, g.a)
+	case gateAnd:
+		binary(" & ")
+	case gateOr:
+		binary(" | ")
+	case gateXor:
+		binary(" ^ ")
+	case gateIte:
+		sb.WriteByte('(')
+		c.format(sb, g.a)
+		sb.WriteString(" ? ")
+		c.format(sb, g.b)
+		sb.WriteString(" : ")
+		c.format(sb, g.c)
+		sb.WriteByte(')')
+	}
+}
+
+// Word is a register as a formula per bit, starting from the least
+// significant one.
+type Word []Bit
+
+// ConstWord returns a word of the given width, holding a known value.
+func ConstWord(value, width int) Word {
+	w := make(Word, width)
+	for i := range w {
+		w[i] = Const(i < 63 && value>>i&1 == 1)
+	}
+
+	return w
+}
+
+// Value returns the value of the word for the given value of A.
+func (c *Circuit) Value(w Word, a int) int {
+	value := 0
+	for i, b := range w {
+		if c.Eval(b, a) {
+			value |= 1 << i
+		}
+	}
+
+	return value
+}
+
+// Concrete returns value of the word, if it doesn't depend on A.
+func (w Word) Concrete() (int, bool) {
+	value := 0
+	for i, b := range w {
+		switch b {
+		case True:
+			value |= 1 << i
+		case False:
+		default:
+			return 0, false
+		}
+	}
+
+	return value, true
+}
+
+func (c *Circuit) xorWords(x, y Word) Word {
+	res := make(Word, len(x))
+	for i := range x {
+		res[i] = c.Xor(x[i], y[i])
+	}
+
+	return res
+}
+
+// low3 keeps only three lowest bits of the word.
+func low3(w Word) Word {
+	res := make(Word, len(w))
+	for i := range res {
+		res[i] = False
+		if i < 3 {
+			res[i] = w[i]
+		}
+	}
+
+	return res
+}
+
+// shiftRight divides the word by 2 to the power of a symbolic shift, with a
+// barrel shifter: every bit of the shift either shifts the word by its power
+// of two or not.
+func (c *Circuit) shiftRight(w, shift Word) Word {
+	res := w
+
+	for k, s := range shift {
+		if s == False {
+			continue
+		}
+
+		shifted := make(Word, len(res))
+		for i := range shifted {
+			shifted[i] = False
+			if k < 63 && i+1<<k < len(res) {
+				shifted[i] = res[i+1<<k]
+			}
+		}
+
+		next := make(Word, len(res))
+		for i := range next {
+			next[i] = c.Ite(s, shifted[i], res[i])
+		}
+
+		res = next
+	}
+
+	return res
+}
+
+// orAll returns whether any bit of the word is set.
+func (c *Circuit) orAll(w Word) Bit {
+	res := False
+	for _, b := range w {
+		res = c.Or(res, b)
+	}
+
+	return res
+}
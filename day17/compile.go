@@ -0,0 +1,322 @@
+package day17
+
+import (
+	"fmt"
+	"slices"
+)
+
+// instKind is an instruction specialized for the kind of its operand.
+type instKind uint8
+
+const (
+	// instFault faults with err, e.g. on an invalid opcode
+	instFault instKind = iota
+
+	// divisions of A by 2 to the power of arg or of register src, stored
+	// into register dst
+	instDivLit
+	instDivReg
+
+	instSetLit // B = arg
+	instSetReg // B = src % 8
+	instXorLit // B ^= arg
+	instXorC   // B ^= C
+	instOutLit // output arg
+	instOutReg // output src % 8
+
+	// instructions, which end a straight run, since they may jump
+	instJump   // jump to arg, unless A is zero
+	instCustom // call custom
+)
+
+// Registers as numbered by dst and src of inst.
+const (
+	regA = iota
+	regB
+	regC
+)
+
+// inst is a pre-decoded instruction. Faults and custom instructions are kept
+// aside, so that instructions stay small.
+type inst struct {
+	kind     instKind
+	dst, src uint8
+	arg      int
+}
+
+// Compiled is a program decoded ahead of time, with combo operands resolved to
+// registers. It runs with the same semantics as the interpreter, including
+// faults and the step limit, but skips decoding and checks of operands on
+// every step, which pays off when the program runs many times, e.g. with
+// different values of register A.
+type Compiled struct {
+	// code holds an instruction decoded at every address, since a jump can
+	// land on an odd one too
+	code []inst
+
+	// faults and custom hold errors of instFault and instructions of
+	// instCustom by their addresses
+	faults []error
+	custom []Instruction
+
+	// straight holds the number of instructions from every address, which
+	// run one after another before a jump or the end of the program
+	straight []int
+
+	maxSteps int
+}
+
+// Compile decodes the program of the VM with its instruction set and step
+// limit. Instructions of DefaultInstructionSet are specialized for their
+// operands, while instructions of custom sets are called as they are.
+func (vm *VM) Compile() *Compiled {
+	c := &Compiled{
+		code:     make([]inst, len(vm.Program)),
+		faults:   make([]error, len(vm.Program)),
+		custom:   make([]Instruction, len(vm.Program)),
+		straight: make([]int, len(vm.Program)),
+		maxSteps: vm.maxSteps(),
+	}
+
+	// a straight run continues with the run two addresses further, unless it
+	// ends right away
+	for ip := len(vm.Program) - 1; ip >= 0; ip-- {
+		c.code[ip], c.faults[ip], c.custom[ip] = decode(vm, ip)
+
+		switch {
+		case c.code[ip].kind >= instJump:
+			c.straight[ip] = 0
+		case ip+2 < len(vm.Program):
+			c.straight[ip] = 1 + c.straight[ip+2]
+		default:
+			c.straight[ip] = 1
+		}
+	}
+
+	return c
+}
+
+// Run executes the program on the machine from the beginning until it halts,
+// like ExecuteProgram does.
+func (c *Compiled) Run(m *Machine) error {
+	_, err := c.run(m, nil, false)
+	return err
+}
+
+// Outputs reports whether the program run on the machine outputs exactly
+// want. It stops at the first number, which differs from want, so most wrong
+// values of the registers are rejected within a few steps, and the machine is
+// left where the program stopped. Custom instructions are checked after they
+// return.
+func (c *Compiled) Outputs(m *Machine, want []int) (bool, error) {
+	ok, err := c.run(m, want, true)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return len(m.Output) == len(want), nil
+}
+
+// run executes the program, and when matching, stops as soon as the output
+// differs from want. Registers are kept in a local array indexed by dst and
+// src of instructions, and are stored into the machine whenever it stops.
+func (c *Compiled) run(m *Machine, want []int, matching bool) (bool, error) {
+	regs := [3]int{m.RegisterA, m.RegisterB, m.RegisterC}
+	output := m.Output
+
+	store := func(ip int) {
+		m.RegisterA, m.RegisterB, m.RegisterC = regs[regA], regs[regB], regs[regC]
+		m.InstructionPointer = ip
+		m.Output = output
+	}
+
+	fault := func(ip int, err error) error {
+		store(ip)
+		return &Fault{IP: ip, Err: err}
+	}
+
+	steps := c.maxSteps
+	limited := steps >= 0
+
+	ip := 0
+	for ip < len(c.code) {
+		if ip < 0 {
+			return false, fault(ip, ErrTruncatedProgram)
+		}
+
+		// the step limit is checked once per straight run: the run is cut
+		// short, if the limit is hit within it, or at the jump after it
+		n := c.straight[ip]
+		end := ip + 2*n
+		exits := end < len(c.code)
+
+		cut := false
+		if limited {
+			if steps < n || (steps == n && exits) {
+				n = steps
+				end = ip + 2*n
+				cut = true
+			}
+
+			steps -= n
+		}
+
+		for ; ip < end; ip += 2 {
+			in := &c.code[ip]
+
+			switch in.kind {
+			case instFault:
+				return false, fault(ip, c.faults[ip])
+			case instDivLit:
+				regs[in.dst] = divide(regs[regA], in.arg)
+			case instDivReg:
+				exp := regs[in.src]
+				if exp < 0 {
+					return false, fault(ip, negativeShiftError(exp))
+				}
+
+				regs[in.dst] = divide(regs[regA], exp)
+			case instSetLit:
+				regs[regB] = in.arg
+			case instSetReg:
+				regs[regB] = regs[in.src] % 8
+			case instXorLit:
+				regs[regB] ^= in.arg
+			case instXorC:
+				regs[regB] ^= regs[regC]
+			case instOutLit, instOutReg:
+				val := in.arg
+				if in.kind == instOutReg {
+					val = regs[in.src] % 8
+				}
+
+				if matching && (len(output) == len(want) || want[len(output)] != val) {
+					store(ip)
+					return false, nil
+				}
+
+				output = append(output, val)
+			}
+		}
+
+		if cut {
+			return false, fault(ip, ErrStepLimit)
+		}
+
+		if !exits {
+			break
+		}
+
+		in := &c.code[ip]
+		steps--
+
+		if in.kind == instJump {
+			if regs[regA] == 0 {
+				ip += 2
+			} else {
+				ip = in.arg
+			}
+
+			continue
+		}
+
+		// custom instructions work on the machine, and may jump, so the
+		// pointer has to be kept
+		store(ip)
+		if err := c.custom[ip](m, in.arg); err != nil {
+			return false, fault(ip, err)
+		}
+
+		regs = [3]int{m.RegisterA, m.RegisterB, m.RegisterC}
+		output = m.Output
+		ip = m.InstructionPointer + 2
+
+		if matching && (len(output) > len(want) || !slices.Equal(output, want[:len(output)])) {
+			store(ip)
+			return false, nil
+		}
+	}
+
+	store(ip)
+
+	return true, nil
+}
+
+// decode returns the instruction at the address, specialized for its operand,
+// and either the fault it raises, or the custom instruction it calls.
+func decode(vm *VM, ip int) (inst, error, Instruction) {
+	fail := func(err error) (inst, error, Instruction) {
+		return inst{kind: instFault}, err, nil
+	}
+
+	if ip+1 >= len(vm.Program) {
+		return fail(ErrTruncatedProgram)
+	}
+
+	opcode, operand := vm.Program[ip], vm.Program[ip+1]
+
+	instructions := vm.InstructionSet
+	if instructions == nil {
+		instructions = DefaultInstructionSet
+	}
+
+	if opcode < 0 || opcode >= len(instructions) {
+		return fail(fmt.Errorf("%w %d", ErrInvalidOpcode, opcode))
+	}
+
+	if operand < 0 || operand > 7 {
+		return fail(fmt.Errorf("%w %d", ErrInvalidOperand, operand))
+	}
+
+	if vm.InstructionSet != nil {
+		return inst{kind: instCustom, arg: operand}, nil, instructions[opcode]
+	}
+
+	// instructions with literal operands
+	switch opcode {
+	case 1:
+		return inst{kind: instXorLit, arg: operand}, nil, nil
+	case 3:
+		return inst{kind: instJump, arg: operand}, nil, nil
+	case 4:
+		return inst{kind: instXorC}, nil, nil
+	}
+
+	if operand == 7 {
+		return fail(reservedComboError(operand))
+	}
+
+	// divisions store into A, B and C by their opcodes
+	var dst uint8
+	switch opcode {
+	case 6:
+		dst = regB
+	case 7:
+		dst = regC
+	}
+
+	// instructions with literal values of combo operands
+	if operand <= 3 {
+		switch opcode {
+		case 0, 6, 7:
+			return inst{kind: instDivLit, dst: dst, arg: operand}, nil, nil
+		case 2:
+			return inst{kind: instSetLit, arg: operand}, nil, nil
+		case 5:
+			return inst{kind: instOutLit, arg: operand}, nil, nil
+		}
+	}
+
+	src := uint8(operand - 4)
+
+	switch opcode {
+	case 0, 6, 7:
+		return inst{kind: instDivReg, dst: dst, src: src}, nil, nil
+	case 2:
+		return inst{kind: instSetReg, src: src}, nil, nil
+	case 5:
+		return inst{kind: instOutReg, src: src}, nil, nil
+	}
+
+	panic(fmt.Errorf("opcode %d is missing from the compiler", opcode))
+}
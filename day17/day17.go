@@ -0,0 +1,159 @@
+// Package day17 runs programs of the Chronospatial Computer. The VM decodes
+// opcodes through an InstructionSet, where every instruction is a function of
+// the machine state, so the instruction set can be replaced or instrumented
+// without touching the VM.
+package day17
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(17, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (string, error) {
+	vm, err := readInput(r)
+	if err != nil {
+		return "", fmt.Errorf("readInput: %w", err)
+	}
+
+	if err := vm.ExecuteProgram(); err != nil {
+		return "", fmt.Errorf("execute program: %w", err)
+	}
+
+	output := make([]string, len(vm.Output))
+	for i, num := range vm.Output {
+		output[i] = strconv.Itoa(num)
+	}
+
+	return strings.Join(output, ","), nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	vm, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	return SolveQuine(vm)
+}
+
+type VM struct {
+	Machine
+
+	Program []int
+
+	// InstructionSet decodes opcodes of the program. When nil,
+	// DefaultInstructionSet is used.
+	InstructionSet InstructionSet
+
+	// MaxSteps limits the number of instructions ExecuteProgram executes.
+	// When zero, DefaultMaxSteps is used, and a negative value disables the
+	// limit.
+	MaxSteps int
+}
+
+// DefaultMaxSteps is the step limit of programs, which don't set their own.
+const DefaultMaxSteps = 1_000_000
+
+// ExecuteProgram runs the program from the beginning until it halts. A fault
+// of the program, or running out of steps, is reported as *Fault.
+func (vm *VM) ExecuteProgram() error {
+	maxSteps := vm.maxSteps()
+
+	for vm.InstructionPointer = 0; !vm.Halted(); {
+		if maxSteps == 0 {
+			return &Fault{IP: vm.InstructionPointer, Err: ErrStepLimit}
+		}
+
+		if err := vm.Step(); err != nil {
+			return err
+		}
+
+		maxSteps--
+	}
+
+	return nil
+}
+
+func (vm *VM) maxSteps() int {
+	if vm.MaxSteps == 0 {
+		return DefaultMaxSteps
+	}
+
+	return vm.MaxSteps
+}
+
+// Halted reports whether the instruction pointer is past the end of the
+// program.
+func (vm *VM) Halted() bool {
+	return vm.InstructionPointer >= len(vm.Program)
+}
+
+// Step executes a single instruction at the instruction pointer, and advances
+// the pointer past it. When the instruction faults, the pointer stays at it.
+func (vm *VM) Step() error {
+	instructions := vm.InstructionSet
+	if instructions == nil {
+		instructions = DefaultInstructionSet
+	}
+
+	ip := vm.InstructionPointer
+	if ip < 0 || ip+1 >= len(vm.Program) {
+		return &Fault{IP: ip, Err: ErrTruncatedProgram}
+	}
+
+	opcode, operand := vm.Program[ip], vm.Program[ip+1]
+
+	if opcode < 0 || opcode >= len(instructions) {
+		return &Fault{IP: ip, Err: fmt.Errorf("%w %d", ErrInvalidOpcode, opcode)}
+	}
+
+	if operand < 0 || operand > 7 {
+		return &Fault{IP: ip, Err: fmt.Errorf("%w %d", ErrInvalidOperand, operand)}
+	}
+
+	if err := instructions[opcode](&vm.Machine, operand); err != nil {
+		vm.InstructionPointer = ip
+		return &Fault{IP: ip, Err: err}
+	}
+
+	vm.InstructionPointer += 2
+	return nil
+}
+
+func readInput(r io.Reader) (*VM, error) {
+	var vm VM
+
+	var program string
+	if _, err := fmt.Fscanf(
+		r,
+		"Register A: %d\nRegister B: %d\nRegister C: %d\n\nProgram: %s",
+		&vm.RegisterA,
+		&vm.RegisterB,
+		&vm.RegisterC,
+		&program,
+	); err != nil {
+		return nil, fmt.Errorf("read program: %w", err)
+	}
+
+	data := strings.Split(program, ",")
+	vm.Program = make([]int, len(data))
+
+	for i, item := range data {
+		num, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("atoi: %w", err)
+		}
+
+		vm.Program[i] = num
+	}
+
+	return &vm, nil
+}
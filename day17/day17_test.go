@@ -0,0 +1,679 @@
+package day17
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example1 = `Register A: 729
+Register B: 0
+Register C: 0
+
+Program: 0,1,5,4,3,0`
+
+const example2 = `Register A: 2024
+Register B: 0
+Register C: 0
+
+Program: 0,3,5,4,3,0`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 17, []aoctest.Example{
+		{Input: example1, Part1: "4,6,3,5,6,3,5,2,1,0"},
+		{Input: example2, Part2: "117440"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 17)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 17)
+}
+
+func TestInstructionSetWrap(t *testing.T) {
+	vm, err := readInput(strings.NewReader(example1))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	executed := map[int]int{}
+	vm.InstructionSet = DefaultInstructionSet.Wrap(func(opcode int, instruction Instruction) Instruction {
+		return func(m *Machine, operand int) error {
+			executed[opcode]++
+			return instruction(m, operand)
+		}
+	})
+
+	if err := vm.ExecuteProgram(); err != nil {
+		t.Fatalf("ExecuteProgram: %v", err)
+	}
+
+	// the program divides 729 by 2 until it's zero, printing A on every iteration
+	want := map[int]int{0: 10, 5: 10, 3: 10}
+	if !maps.Equal(executed, want) {
+		t.Errorf("got %v, want %v", executed, want)
+	}
+}
+
+func TestCustomInstructionSet(t *testing.T) {
+	// an instruction set, where opcode 0 increments A, and opcode 1 outputs it
+	vm := VM{
+		Program: []int{0, 0, 0, 0, 1, 0},
+		InstructionSet: InstructionSet{
+			func(m *Machine, _ int) error { m.RegisterA++; return nil },
+			func(m *Machine, _ int) error { m.Output = append(m.Output, m.RegisterA); return nil },
+		},
+	}
+
+	if err := vm.ExecuteProgram(); err != nil {
+		t.Fatalf("ExecuteProgram: %v", err)
+	}
+
+	if !slices.Equal(vm.Output, []int{2}) {
+		t.Errorf("got %v, want [2]", vm.Output)
+	}
+}
+
+func TestDisassembleRoundTrip(t *testing.T) {
+	programs := [][]int{
+		{0, 1, 5, 4, 3, 0},
+		{0, 3, 5, 4, 3, 0},
+		{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 0, 5, 5, 3, 0},
+		{6, 7, 4, 4, 5, 6},
+	}
+
+	for _, program := range programs {
+		listing, err := DefaultMnemonics.Disassemble(program)
+		if err != nil {
+			t.Fatalf("Disassemble(%v): %v", program, err)
+		}
+
+		got, err := DefaultMnemonics.Assemble(listing)
+		if err != nil {
+			t.Fatalf("Assemble(%q): %v", listing, err)
+		}
+
+		if !slices.Equal(got, program) {
+			t.Errorf("round trip of %v through %q gave %v", program, listing, got)
+		}
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	listing, err := DefaultMnemonics.Disassemble([]int{2, 4, 1, 5, 4, 0, 5, 5, 3, 0})
+	if err != nil {
+		t.Fatalf("Disassemble: %v", err)
+	}
+
+	want := "bst A\nbxl 5\nbxc 0\nout B\njnz 0\n"
+	if listing != want {
+		t.Errorf("got %q, want %q", listing, want)
+	}
+
+	for _, program := range [][]int{{0, 1, 5}, {8, 0}} {
+		if _, err := DefaultMnemonics.Disassemble(program); err == nil {
+			t.Errorf("Disassemble(%v): expected an error", program)
+		}
+	}
+}
+
+func TestAssemble(t *testing.T) {
+	program, err := DefaultMnemonics.Assemble("; halve A until zero\nadv 1\n\nout A ; print it\njnz 0\n")
+	if err != nil {
+		t.Fatalf("Assemble: %v", err)
+	}
+
+	if want := []int{0, 1, 5, 4, 3, 0}; !slices.Equal(program, want) {
+		t.Errorf("got %v, want %v", program, want)
+	}
+
+	for _, listing := range []string{"mul 1", "adv", "adv 8", "bxl A", "out D"} {
+		if _, err := DefaultMnemonics.Assemble(listing); err == nil {
+			t.Errorf("Assemble(%q): expected an error", listing)
+		}
+	}
+}
+
+func TestDebugger(t *testing.T) {
+	vm, err := readInput(strings.NewReader(example1))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	d := NewDebugger(vm)
+
+	if ok, err := d.Step(); !ok || err != nil {
+		t.Fatalf("Step: got %t, %v", ok, err)
+	}
+
+	if got, want := d.Trace[0], (TraceStep{Step: 1, IP: 0, Opcode: 0, Operand: 1, A: 364, Output: []int{}}); !traceStepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	bp, err := ParseBreakpoint("A<=45")
+	if err != nil {
+		t.Fatalf("ParseBreakpoint: %v", err)
+	}
+
+	d.Breakpoints = []Breakpoint{bp, AtIP(4)}
+
+	// the first stop is at the jump right after the first output
+	hit, ok, err := d.Continue()
+	if err != nil {
+		t.Fatalf("Continue: %v", err)
+	}
+
+	if !ok || hit.Name != "ip=4" {
+		t.Fatalf("got breakpoint %q (hit %t), want ip=4", hit.Name, ok)
+	}
+
+	d.Breakpoints = []Breakpoint{bp}
+
+	hit, ok, err = d.Continue()
+	if err != nil {
+		t.Fatalf("Continue: %v", err)
+	}
+
+	if !ok || hit.Name != "A<=45" || vm.RegisterA != 45 {
+		t.Fatalf("got breakpoint %q (hit %t) with A=%d, want A<=45 with A=45", hit.Name, ok, vm.RegisterA)
+	}
+
+	d.Breakpoints = nil
+
+	if _, ok, err := d.Continue(); ok || err != nil {
+		t.Fatalf("Continue: got %t, %v, expected the program to halt", ok, err)
+	}
+
+	if got, want := vm.Output, []int{4, 6, 3, 5, 6, 3, 5, 2, 1, 0}; !slices.Equal(got, want) {
+		t.Errorf("got output %v, want %v", got, want)
+	}
+
+	if len(d.Trace) != 30 {
+		t.Errorf("got %d steps, want 30", len(d.Trace))
+	}
+
+	var text, js strings.Builder
+	if err := d.WriteTrace(&text, "text"); err != nil {
+		t.Fatalf("WriteTrace text: %v", err)
+	}
+
+	if lines := strings.Count(text.String(), "\n"); lines != 30 {
+		t.Errorf("got %d lines of text trace, want 30", lines)
+	}
+
+	if err := d.WriteTrace(&js, "json"); err != nil {
+		t.Fatalf("WriteTrace json: %v", err)
+	}
+
+	var trace []TraceStep
+	if err := json.Unmarshal([]byte(js.String()), &trace); err != nil {
+		t.Fatalf("unmarshal trace: %v", err)
+	}
+
+	if !slices.EqualFunc(trace, d.Trace, traceStepEqual) {
+		t.Error("JSON trace differs from the recorded one")
+	}
+}
+
+func TestParseBreakpoint(t *testing.T) {
+	m := &Machine{RegisterA: 8, RegisterB: 3, RegisterC: 0, InstructionPointer: 4}
+
+	for s, want := range map[string]bool{
+		"ip=4":  true,
+		"A==8":  true,
+		"A!=8":  false,
+		"B>3":   false,
+		"B>=3":  true,
+		"C<1":   true,
+		"a = 8": true,
+	} {
+		bp, err := ParseBreakpoint(s)
+		if err != nil {
+			t.Errorf("ParseBreakpoint(%q): %v", s, err)
+			continue
+		}
+
+		if got := bp.Condition(m); got != want {
+			t.Errorf("%q: got %t, want %t", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"A", "D=1", "A=x"} {
+		if _, err := ParseBreakpoint(s); err == nil {
+			t.Errorf("ParseBreakpoint(%q): expected an error", s)
+		}
+	}
+}
+
+func traceStepEqual(a, b TraceStep) bool {
+	return a.Step == b.Step && a.IP == b.IP && a.Opcode == b.Opcode && a.Operand == b.Operand &&
+		a.A == b.A && a.B == b.B && a.C == b.C && slices.Equal(a.Output, b.Output)
+}
+
+func TestAnalyzeLoop(t *testing.T) {
+	for _, tt := range []struct {
+		program []int
+		want    Loop
+	}{
+		{[]int{0, 3, 5, 4, 3, 0}, Loop{Shift: 3, Outputs: 1}},
+		{[]int{0, 2, 2, 4, 5, 5, 3, 0}, Loop{Shift: 2, Outputs: 1}},
+		{[]int{2, 4, 5, 5, 1, 3, 5, 5, 0, 1, 3, 0}, Loop{Shift: 1, Outputs: 2}},
+	} {
+		got, err := AnalyzeLoop(tt.program)
+		if err != nil {
+			t.Errorf("AnalyzeLoop(%v): %v", tt.program, err)
+		} else if got != tt.want {
+			t.Errorf("AnalyzeLoop(%v): got %+v, want %+v", tt.program, got, tt.want)
+		}
+	}
+
+	for _, program := range [][]int{
+		{0, 3, 5, 4, 3},
+		{0, 3, 5, 4, 3, 2},
+		{0, 4, 5, 4, 3, 0},
+		{0, 1, 0, 1, 5, 4, 3, 0},
+		{5, 4, 3, 0},
+		{0, 3, 3, 0, 5, 4, 3, 0},
+	} {
+		if loop, err := AnalyzeLoop(program); err == nil {
+			t.Errorf("AnalyzeLoop(%v): got %+v, expected an error", program, loop)
+		}
+	}
+}
+
+func TestSolveQuine(t *testing.T) {
+	// a loop dropping 2 bits of A per iteration, with the division at the start
+	vm := &VM{Program: []int{0, 2, 2, 4, 5, 5, 3, 0}}
+
+	got, err := SolveQuine(vm)
+	if err != nil {
+		t.Fatalf("SolveQuine: %v", err)
+	}
+
+	if want, ok := searchQuine(vm, quineSearchLimit); !ok || got != want {
+		t.Errorf("got %d, want %d found by brute force", got, want)
+	}
+
+	vm.RegisterA = got
+	vm.Output = nil
+
+	if err := vm.ExecuteProgram(); err != nil {
+		t.Fatalf("ExecuteProgram: %v", err)
+	}
+
+	if !slices.Equal(vm.Output, vm.Program) {
+		t.Errorf("A=%d outputs %v", got, vm.Output)
+	}
+}
+
+func TestSolveQuineFallback(t *testing.T) {
+	// an instruction set, where opcode 0 outputs itself followed by A
+	vm := &VM{
+		Program: []int{0, 5},
+		InstructionSet: InstructionSet{
+			func(m *Machine, _ int) error { m.Output = append(m.Output, 0, m.RegisterA%8); return nil },
+		},
+	}
+
+	if got, err := SolveQuine(vm); err != nil || got != 5 {
+		t.Errorf("got %d (%v), want 5", got, err)
+	}
+
+	// a program without a loop can't output more than a number per instruction
+	vm = &VM{Program: []int{2, 4, 5, 5}}
+
+	if _, err := SolveQuine(vm); !errors.Is(err, ErrNoQuine) {
+		t.Errorf("got %v, want ErrNoQuine", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		vm      VM
+		want    error
+		wantIP  int
+		wantOut []int
+	}{
+		{"reserved combo operand", VM{Program: []int{5, 0, 5, 7}}, ErrInvalidOperand, 2, []int{0}},
+		{"operand out of range", VM{Program: []int{1, 9}}, ErrInvalidOperand, 0, nil},
+		{"invalid opcode", VM{Program: []int{5, 1, 8, 0}}, ErrInvalidOpcode, 2, []int{1}},
+		{"truncated program", VM{Program: []int{5, 1, 5}}, ErrTruncatedProgram, 2, []int{1}},
+		{"jump into the operand", VM{Machine: Machine{RegisterA: 1}, Program: []int{3, 3, 5, 4}}, ErrTruncatedProgram, 3, nil},
+		{"endless loop", VM{Machine: Machine{RegisterA: 1}, Program: []int{3, 0}, MaxSteps: 100}, ErrStepLimit, 0, nil},
+		{"negative shift", VM{Machine: Machine{RegisterA: 5, RegisterB: -1}, Program: []int{5, 4, 0, 5}}, ErrNegativeShift, 2, []int{5}},
+		{"negative A shifting itself", VM{Machine: Machine{RegisterA: -1}, Program: []int{6, 4}}, ErrNegativeShift, 0, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled := tt.vm
+			compiledErr := compiled.Compile().Run(&compiled.Machine)
+
+			err := tt.vm.ExecuteProgram()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+
+			var fault *Fault
+			if !errors.As(err, &fault) || fault.IP != tt.wantIP {
+				t.Errorf("got fault %v, want it at ip %d", err, tt.wantIP)
+			}
+
+			if !slices.Equal(tt.vm.Output, tt.wantOut) {
+				t.Errorf("got output %v, want %v", tt.vm.Output, tt.wantOut)
+			}
+
+			if fmt.Sprint(compiledErr) != fmt.Sprint(err) {
+				t.Errorf("compiled program faults with %v", compiledErr)
+			}
+		})
+	}
+}
+
+func TestDebugToolFault(t *testing.T) {
+	input := "Register A: 0\nRegister B: 0\nRegister C: 0\n\nProgram: 5,4,0,4"
+
+	for format, want := range map[string]string{"text": "fault at ip 2", "json": `"opcode": 5`} {
+		var sb strings.Builder
+
+		err := debugTool([]string{"-a", "-1", "--trace", format}, strings.NewReader(input), &sb)
+		if !errors.Is(err, ErrNegativeShift) {
+			t.Errorf("%s: got %v, want ErrNegativeShift", format, err)
+		}
+
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("%s: %q is missing from:\n%s", format, want, sb.String())
+		}
+	}
+}
+
+func TestDebugToolSteps(t *testing.T) {
+	var sb strings.Builder
+
+	if err := debugTool([]string{"--steps", "2"}, strings.NewReader(example1), &sb); err != nil {
+		t.Fatalf("debugTool: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 3 || lines[2] != "stopped after 2 steps, next ip=4" {
+		t.Errorf("got:\n%s", sb.String())
+	}
+}
+
+func TestDebuggerStepLimit(t *testing.T) {
+	d := NewDebugger(&VM{Machine: Machine{RegisterA: 1}, Program: []int{5, 4, 3, 0}, MaxSteps: 10})
+
+	_, _, err := d.Continue()
+	if !errors.Is(err, ErrStepLimit) {
+		t.Fatalf("got %v, want ErrStepLimit", err)
+	}
+
+	if len(d.Trace) != 10 {
+		t.Errorf("got %d steps, want 10", len(d.Trace))
+	}
+}
+
+func TestCompileMatchesInterpreter(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+
+	// an instruction set with a jump and a fault of its own, on top of the
+	// default one
+	custom := append(slices.Clone(DefaultInstructionSet),
+		func(m *Machine, operand int) error {
+			m.InstructionPointer = operand - 4
+			return nil
+		},
+		func(m *Machine, operand int) error {
+			if m.RegisterB > m.RegisterA {
+				return fmt.Errorf("B exceeds A by %d", m.RegisterB-m.RegisterA)
+			}
+
+			return nil
+		},
+	)
+
+	for i := range 20000 {
+		program := make([]int, 1+r.Intn(16))
+		for j := range program {
+			// mostly valid programs, so that they run long enough
+			program[j] = r.Intn(8)
+			if r.Intn(50) == 0 {
+				program[j] = 7 + r.Intn(4)
+			}
+		}
+
+		initial := Machine{RegisterA: r.Intn(1 << 20), RegisterB: r.Intn(64), RegisterC: r.Intn(64)}
+
+		// hand-written inputs may hold negative registers
+		if r.Intn(4) == 0 {
+			initial.RegisterA, initial.RegisterB, initial.RegisterC = -initial.RegisterA, r.Intn(64)-32, r.Intn(64)-32
+		}
+
+		interpreted := VM{Machine: initial, Program: program, MaxSteps: 200}
+		if i%2 == 1 {
+			interpreted.InstructionSet = custom
+		}
+
+		compiled := interpreted.Compile()
+		m := initial
+
+		wantErr := interpreted.ExecuteProgram()
+		gotErr := compiled.Run(&m)
+
+		if fmt.Sprint(gotErr) != fmt.Sprint(wantErr) || !machinesEqual(m, interpreted.Machine) {
+			t.Fatalf(
+				"program %v with %+v (custom set %t):\ncompiled %+v, %v\ninterpreted %+v, %v",
+				program, initial, i%2 == 1, m, gotErr, interpreted.Machine, wantErr,
+			)
+		}
+
+		// matching the output stops at the first extra number
+		output := interpreted.Output
+
+		m = initial
+		if ok, err := compiled.Outputs(&m, output); ok != (wantErr == nil) || fmt.Sprint(err) != fmt.Sprint(wantErr) {
+			t.Fatalf("program %v with %+v: Outputs got %t, %v", program, initial, ok, err)
+		}
+
+		m = initial
+		if ok, err := compiled.Outputs(&m, output[:max(len(output)-1, 0)]); len(output) > 0 && (ok || err != nil) {
+			t.Fatalf("program %v with %+v: Outputs of a prefix got %t, %v", program, initial, ok, err)
+		}
+	}
+}
+
+func machinesEqual(a, b Machine) bool {
+	return a.RegisterA == b.RegisterA && a.RegisterB == b.RegisterB && a.RegisterC == b.RegisterC &&
+		a.InstructionPointer == b.InstructionPointer && slices.Equal(a.Output, b.Output)
+}
+
+// the program from the second example, run with many values of A
+func BenchmarkInterpreter(b *testing.B) {
+	vm := VM{Program: []int{0, 3, 5, 4, 3, 0}}
+
+	for i := range b.N {
+		vm.RegisterA = i
+		vm.Output = vm.Output[:0]
+
+		if err := vm.ExecuteProgram(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiled(b *testing.B) {
+	vm := VM{Program: []int{0, 3, 5, 4, 3, 0}}
+	compiled := vm.Compile()
+
+	for i := range b.N {
+		vm.RegisterA = i
+		vm.Output = vm.Output[:0]
+
+		if err := compiled.Run(&vm.Machine); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// searchProgram has the shape of puzzle inputs: a loop over A, which mixes
+// the lowest bits of A with higher ones, outputs a number and shifts A by 3.
+var searchProgram = []int{2, 4, 1, 1, 7, 5, 1, 5, 4, 0, 0, 3, 5, 5, 3, 0}
+
+// benchmarks of the brute force of part 2, where a run of the program checks a
+// single candidate for A
+func BenchmarkSearchInterpreter(b *testing.B) {
+	vm := VM{Program: searchProgram}
+
+	for i := range b.N {
+		vm.RegisterA = i
+		vm.Output = vm.Output[:0]
+
+		if err := vm.ExecuteProgram(); err != nil {
+			b.Fatal(err)
+		}
+
+		if slices.Equal(vm.Output, vm.Program) {
+			b.Fatalf("A=%d outputs the program", i)
+		}
+	}
+}
+
+func BenchmarkSearchCompiled(b *testing.B) {
+	vm := VM{Program: searchProgram}
+	compiled := vm.Compile()
+
+	for i := range b.N {
+		vm.RegisterA = i
+		vm.Output = vm.Output[:0]
+
+		ok, err := compiled.Outputs(&vm.Machine, vm.Program)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if ok {
+			b.Fatalf("A=%d outputs the program", i)
+		}
+	}
+}
+
+func TestCircuit(t *testing.T) {
+	c := NewCircuit()
+
+	a3, a5 := c.Var(3), c.Var(5)
+
+	if got, want := c.Format(c.Xor(a3, c.Not(a5))), "(a3 ^ !a5)"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	for name, tt := range map[string]struct{ got, want Bit }{
+		"x & !x":         {c.And(a3, c.Not(a3)), False},
+		"x ^ x":          {c.Xor(a5, a5), False},
+		"!!x":            {c.Not(c.Not(a3)), a3},
+		"x ? 1 : 0":      {c.Ite(a3, True, False), a3},
+		"shared formula": {c.Or(a5, a3), c.Or(a3, a5)},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %s, want %s", name, c.Format(tt.got), c.Format(tt.want))
+		}
+	}
+}
+
+func TestSymbolicPathsMatchExecution(t *testing.T) {
+	vm := &VM{Program: []int{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 0, 5, 5, 3, 0}}
+
+	c := NewCircuit()
+
+	var paths []Path
+	for path, err := range vm.SymbolicPaths(c, 63, 8) {
+		if err != nil {
+			t.Fatalf("SymbolicPaths: %v", err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	r := rand.New(rand.NewSource(17))
+
+	for range 200 {
+		a := r.Intn(1 << 24)
+
+		concrete := *vm
+		concrete.RegisterA = a
+		concrete.Output = nil
+
+		if err := concrete.ExecuteProgram(); err != nil {
+			t.Fatalf("ExecuteProgram: %v", err)
+		}
+
+		// the paths are exclusive, so exactly one of them is taken
+		taken := slices.IndexFunc(paths, func(p Path) bool {
+			return !slices.ContainsFunc(p.Conditions, func(b Bit) bool { return !c.Eval(b, a) })
+		})
+		if taken == -1 {
+			t.Fatalf("A=%d takes no path", a)
+		}
+
+		got := make([]int, len(paths[taken].Output))
+		for i, value := range paths[taken].Output {
+			got[i] = c.Value(value, a)
+		}
+
+		if !slices.Equal(got, concrete.Output) {
+			t.Errorf("A=%d: symbolic output %v, concrete output %v", a, got, concrete.Output)
+		}
+	}
+}
+
+func TestSolveOutput(t *testing.T) {
+	for _, program := range [][]int{
+		{0, 3, 5, 4, 3, 0},
+		{0, 2, 2, 4, 5, 5, 3, 0},
+		{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 0, 5, 5, 3, 0},
+	} {
+		got, err := SolveOutput(&VM{Program: program}, program)
+		if err != nil {
+			t.Fatalf("SolveOutput(%v): %v", program, err)
+		}
+
+		if want, err := SolveQuine(&VM{Program: program}); err != nil || got != want {
+			t.Errorf("SolveOutput(%v): got %d, SolveQuine found %d (%v)", program, got, want, err)
+		}
+	}
+
+	// any output, not just the program itself, e.g. the one of the first
+	// example, checked by brute force
+	vm := &VM{Program: []int{0, 1, 5, 4, 3, 0}}
+	want := []int{4, 6, 3, 5, 6, 3, 5, 2, 1, 0}
+
+	got, err := SolveOutput(vm, want)
+	if err != nil {
+		t.Fatalf("SolveOutput: %v", err)
+	}
+
+	for a := range got + 1 {
+		vm.RegisterA = a
+		vm.Output = nil
+
+		if err := vm.ExecuteProgram(); err != nil {
+			t.Fatalf("ExecuteProgram: %v", err)
+		}
+
+		if slices.Equal(vm.Output, want) != (a == got) {
+			t.Fatalf("A=%d outputs %v, while the lowest A is %d", a, vm.Output, got)
+		}
+	}
+
+	if _, err := SolveOutput(vm, []int{7, 7}); err == nil {
+		t.Error("expected an error for an output, which the program can't produce")
+	}
+}
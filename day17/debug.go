@@ -0,0 +1,298 @@
+package day17
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(17, registry.Tool{
+		Name:        "debug",
+		Description: "run the program step by step, stopping at breakpoints, and print the trace",
+		Run:         debugTool,
+	})
+}
+
+// TraceStep is the state of the machine right after an instruction was
+// executed.
+type TraceStep struct {
+	Step    int   `json:"step"`
+	IP      int   `json:"ip"`
+	Opcode  int   `json:"opcode"`
+	Operand int   `json:"operand"`
+	A       int   `json:"a"`
+	B       int   `json:"b"`
+	C       int   `json:"c"`
+	Output  []int `json:"output"`
+}
+
+// Breakpoint stops the execution when its condition holds after a step.
+type Breakpoint struct {
+	Name      string
+	Condition func(m *Machine) bool
+}
+
+// AtIP is a breakpoint at the instruction with the given address, i.e. it
+// stops right before the instruction is executed.
+func AtIP(ip int) Breakpoint {
+	return Breakpoint{
+		Name:      fmt.Sprintf("ip=%d", ip),
+		Condition: func(m *Machine) bool { return m.InstructionPointer == ip },
+	}
+}
+
+var comparisons = []struct {
+	op      string
+	compare func(a, b int) bool
+}{
+	// longer operators go first, so that "<=" is not taken for "<"
+	{"==", func(a, b int) bool { return a == b }},
+	{"!=", func(a, b int) bool { return a != b }},
+	{"<=", func(a, b int) bool { return a <= b }},
+	{">=", func(a, b int) bool { return a >= b }},
+	{"=", func(a, b int) bool { return a == b }},
+	{"<", func(a, b int) bool { return a < b }},
+	{">", func(a, b int) bool { return a > b }},
+}
+
+// ParseBreakpoint parses a breakpoint such as "ip=4", "A==0" or "B>5".
+// The left side is one of ip, A, B and C, and the right side is a number.
+func ParseBreakpoint(s string) (Breakpoint, error) {
+	for _, c := range comparisons {
+		left, right, ok := strings.Cut(s, c.op)
+		if !ok {
+			continue
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(right))
+		if err != nil {
+			return Breakpoint{}, fmt.Errorf("breakpoint %q: invalid value: %w", s, err)
+		}
+
+		var get func(m *Machine) int
+
+		switch strings.TrimSpace(left) {
+		case "ip", "IP":
+			get = func(m *Machine) int { return m.InstructionPointer }
+		case "A", "a":
+			get = func(m *Machine) int { return m.RegisterA }
+		case "B", "b":
+			get = func(m *Machine) int { return m.RegisterB }
+		case "C", "c":
+			get = func(m *Machine) int { return m.RegisterC }
+		default:
+			return Breakpoint{}, fmt.Errorf("breakpoint %q: unknown register %q", s, left)
+		}
+
+		return Breakpoint{
+			Name:      s,
+			Condition: func(m *Machine) bool { return c.compare(get(m), value) },
+		}, nil
+	}
+
+	return Breakpoint{}, fmt.Errorf("breakpoint %q: expected a comparison", s)
+}
+
+// Debugger executes the program of the VM step by step, and records every
+// step into the trace.
+type Debugger struct {
+	VM          *VM
+	Breakpoints []Breakpoint
+	Trace       []TraceStep
+}
+
+// NewDebugger returns a debugger, which starts executing the program from
+// its beginning.
+func NewDebugger(vm *VM) *Debugger {
+	vm.InstructionPointer = 0
+
+	return &Debugger{VM: vm}
+}
+
+// Step executes a single instruction, and reports whether there was one to
+// execute. A faulting instruction is not recorded into the trace.
+func (d *Debugger) Step() (bool, error) {
+	vm := d.VM
+	if vm.Halted() {
+		return false, nil
+	}
+
+	if limit := vm.maxSteps(); len(d.Trace) == limit {
+		return false, &Fault{IP: vm.InstructionPointer, Err: ErrStepLimit}
+	}
+
+	ip := vm.InstructionPointer
+
+	if err := vm.Step(); err != nil {
+		return false, err
+	}
+
+	d.Trace = append(d.Trace, TraceStep{
+		Step:    len(d.Trace) + 1,
+		IP:      ip,
+		Opcode:  vm.Program[ip],
+		Operand: vm.Program[ip+1],
+		A:       vm.RegisterA,
+		B:       vm.RegisterB,
+		C:       vm.RegisterC,
+		Output:  slices.Clone(vm.Output),
+	})
+
+	return true, nil
+}
+
+// Continue executes the program until a breakpoint is hit, the program halts
+// or faults. Breakpoints are checked after every step, so continuing from a
+// breakpoint always makes progress. It returns the breakpoint that was hit,
+// or false if the program halted.
+func (d *Debugger) Continue() (Breakpoint, bool, error) {
+	return d.ContinueFor(-1)
+}
+
+// ContinueFor is like Continue, but stops after n steps too, unless n is
+// negative. It returns false, when the steps run out before a breakpoint is
+// hit.
+func (d *Debugger) ContinueFor(n int) (Breakpoint, bool, error) {
+	for i := 0; n < 0 || i < n; i++ {
+		ok, err := d.Step()
+		if err != nil {
+			return Breakpoint{}, false, err
+		}
+
+		if !ok {
+			return Breakpoint{}, false, nil
+		}
+
+		for _, bp := range d.Breakpoints {
+			if bp.Condition(&d.VM.Machine) {
+				return bp, true, nil
+			}
+		}
+	}
+
+	return Breakpoint{}, false, nil
+}
+
+// WriteTrace writes the trace recorded so far, either as "text" with a step
+// per line, or as "json".
+func (d *Debugger) WriteTrace(w io.Writer, format string) error {
+	switch format {
+	case "text":
+		for _, step := range d.Trace {
+			output := make([]string, len(step.Output))
+			for i, num := range step.Output {
+				output[i] = strconv.Itoa(num)
+			}
+
+			if _, err := fmt.Fprintf(
+				w,
+				"%4d  ip=%-3d %d,%d  A=%d B=%d C=%d  output=%s\n",
+				step.Step,
+				step.IP,
+				step.Opcode,
+				step.Operand,
+				step.A,
+				step.B,
+				step.C,
+				strings.Join(output, ","),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		trace := d.Trace
+		if trace == nil {
+			trace = []TraceStep{}
+		}
+
+		return enc.Encode(trace)
+	}
+
+	return fmt.Errorf("unknown trace format %q", format)
+}
+
+func debugTool(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("debug", flag.ContinueOnError)
+	registerA := fs.String("a", "", "override the initial value of register A")
+	format := fs.String("trace", "text", "trace format, text or json")
+	steps := fs.Int("steps", 0, "stop after the given number of steps, or run on if 0")
+
+	var breakpoints []Breakpoint
+	fs.Func("break", "stop when the condition holds, e.g. ip=4 or A==0 (repeatable)", func(s string) error {
+		bp, err := ParseBreakpoint(s)
+		if err != nil {
+			return err
+		}
+
+		breakpoints = append(breakpoints, bp)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *steps < 0 {
+		return fmt.Errorf("negative number of steps %d", *steps)
+	}
+
+	vm, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	if *registerA != "" {
+		vm.RegisterA, err = strconv.Atoi(*registerA)
+		if err != nil {
+			return fmt.Errorf("parse register A: %w", err)
+		}
+	}
+
+	d := NewDebugger(vm)
+	d.Breakpoints = breakpoints
+
+	limit := *steps
+	if limit == 0 {
+		limit = -1
+	}
+
+	bp, hit, fault := d.ContinueFor(limit)
+
+	if err := d.WriteTrace(out, *format); err != nil {
+		return fmt.Errorf("write trace: %w", err)
+	}
+
+	if *format != "text" {
+		return fault
+	}
+
+	switch {
+	case fault != nil:
+		_, err = fmt.Fprintf(out, "fault at %v\n", fault)
+	case hit:
+		_, err = fmt.Fprintf(out, "stopped at breakpoint %s, next ip=%d\n", bp.Name, vm.InstructionPointer)
+	case vm.Halted():
+		_, err = fmt.Fprintln(out, "halted")
+	default:
+		_, err = fmt.Fprintf(out, "stopped after %d steps, next ip=%d\n", len(d.Trace), vm.InstructionPointer)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the fault is returned in either format, so that scripts can tell
+	return fault
+}
@@ -0,0 +1,185 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Machine is the state of the chronospatial computer, which instructions
+// operate on.
+type Machine struct {
+	RegisterA int
+	RegisterB int
+	RegisterC int
+
+	InstructionPointer int
+
+	Output []int
+}
+
+// Faults of the program. They are wrapped into Fault, which tells where the
+// program failed.
+var (
+	ErrInvalidOperand   = errors.New("invalid operand")
+	ErrInvalidOpcode    = errors.New("invalid opcode")
+	ErrTruncatedProgram = errors.New("truncated program")
+	ErrStepLimit        = errors.New("step limit exceeded")
+	ErrNegativeShift    = errors.New("negative shift")
+)
+
+// Fault is an error raised by the instruction at IP.
+type Fault struct {
+	IP  int
+	Err error
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("ip %d: %v", f.IP, f.Err)
+}
+
+func (f *Fault) Unwrap() error {
+	return f.Err
+}
+
+// Combo returns value of a combo operand.
+func (m *Machine) Combo(operand int) (int, error) {
+	switch {
+	case operand >= 0 && operand <= 3:
+		return operand, nil
+	case operand == 4:
+		return m.RegisterA, nil
+	case operand == 5:
+		return m.RegisterB, nil
+	case operand == 6:
+		return m.RegisterC, nil
+	}
+
+	return 0, reservedComboError(operand)
+}
+
+func reservedComboError(operand int) error {
+	return fmt.Errorf("%w: combo operand %d is reserved", ErrInvalidOperand, operand)
+}
+
+// Instruction executes a single instruction with the given operand. The
+// instruction pointer is advanced past the instruction after it returns, so
+// jumps have to account for that.
+type Instruction func(m *Machine, operand int) error
+
+// InstructionSet maps opcodes to instructions.
+type InstructionSet []Instruction
+
+// Wrap returns an instruction set, in which every instruction is replaced by
+// the result of wrap. It is useful to instrument existing instructions.
+func (s InstructionSet) Wrap(wrap func(opcode int, instruction Instruction) Instruction) InstructionSet {
+	res := make(InstructionSet, len(s))
+	for opcode, instruction := range s {
+		res[opcode] = wrap(opcode, instruction)
+	}
+
+	return res
+}
+
+// DefaultInstructionSet is the instruction set of the puzzle.
+var DefaultInstructionSet = InstructionSet{adv, bxl, bst, jnz, bxc, out, bdv, cdv}
+
+func dv(m *Machine, operand int) (int, error) {
+	combo, err := m.Combo(operand)
+	if err != nil {
+		return 0, err
+	}
+
+	if combo < 0 {
+		return 0, negativeShiftError(combo)
+	}
+
+	return divide(m.RegisterA, combo), nil
+}
+
+func negativeShiftError(exp int) error {
+	return fmt.Errorf("%w: A divided by 2 to the power of %d", ErrNegativeShift, exp)
+}
+
+// divide divides num by 2 to the power of exp, which must not be negative.
+func divide(num, exp int) int {
+	if exp >= 63 {
+		// the denominator would overflow, while the quotient is zero anyway
+		return 0
+	}
+
+	// a shift is much faster than a division, but rounds down instead of
+	// towards zero, so negative numbers are biased
+	bias := (num >> 63) & (1<<exp - 1)
+
+	return (num + bias) >> exp
+}
+
+func adv(m *Machine, operand int) error {
+	res, err := dv(m, operand)
+	if err != nil {
+		return err
+	}
+
+	m.RegisterA = res
+	return nil
+}
+
+func bxl(m *Machine, operand int) error {
+	m.RegisterB ^= operand
+	return nil
+}
+
+func bst(m *Machine, operand int) error {
+	combo, err := m.Combo(operand)
+	if err != nil {
+		return err
+	}
+
+	m.RegisterB = combo % 8
+	return nil
+}
+
+func jnz(m *Machine, operand int) error {
+	if m.RegisterA == 0 {
+		return nil
+	}
+
+	m.InstructionPointer = operand - 2
+	return nil
+}
+
+func bxc(m *Machine, _ int) error {
+	m.RegisterB ^= m.RegisterC
+	return nil
+}
+
+func out(m *Machine, operand int) error {
+	combo, err := m.Combo(operand)
+	if err != nil {
+		return err
+	}
+
+	val := combo % 8
+	m.Output = append(m.Output, val)
+	return nil
+}
+
+func bdv(m *Machine, operand int) error {
+	res, err := dv(m, operand)
+	if err != nil {
+		return err
+	}
+
+	m.RegisterB = res
+	return nil
+}
+
+func cdv(m *Machine, operand int) error {
+	res, err := dv(m, operand)
+	if err != nil {
+		return err
+	}
+
+	m.RegisterC = res
+	return nil
+}
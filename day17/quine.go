@@ -0,0 +1,164 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoQuine is returned when no value of register A makes the program output
+// itself.
+var ErrNoQuine = errors.New("no value of A makes the program output itself")
+
+// quineSearchLimit bounds the brute force search of programs, which do not
+// run a single loop over A.
+const quineSearchLimit = 1 << 20
+
+// Loop describes a program, which runs a single loop over register A: the
+// loop drops the lowest Shift bits of A and outputs Outputs values on every
+// iteration, and jumps back to the beginning until A is zero.
+type Loop struct {
+	Shift   int
+	Outputs int
+}
+
+// AnalyzeLoop detects the loop structure of a program written in
+// DefaultInstructionSet.
+func AnalyzeLoop(program []int) (Loop, error) {
+	if len(program) < 4 || len(program)%2 != 0 {
+		return Loop{}, fmt.Errorf("program of length %d is too short or truncated", len(program))
+	}
+
+	if program[len(program)-2] != 3 || program[len(program)-1] != 0 {
+		return Loop{}, errors.New("program doesn't end with a jump to the beginning")
+	}
+
+	var loop Loop
+
+	for ip := 0; ip < len(program)-2; ip += 2 {
+		opcode, operand := program[ip], program[ip+1]
+
+		switch opcode {
+		case 0:
+			if loop.Shift != 0 {
+				return Loop{}, fmt.Errorf("A is divided more than once, again at %d", ip)
+			}
+
+			if operand < 1 || operand > 3 {
+				return Loop{}, fmt.Errorf("A is divided by a non-constant or by 1 at %d", ip)
+			}
+
+			loop.Shift = operand
+		case 3:
+			return Loop{}, fmt.Errorf("program jumps in the middle of the loop at %d", ip)
+		case 5:
+			loop.Outputs++
+		}
+	}
+
+	if loop.Shift == 0 {
+		return Loop{}, errors.New("A is never divided")
+	}
+
+	if loop.Outputs == 0 || len(program)%loop.Outputs != 0 {
+		return Loop{}, fmt.Errorf("%d outputs per iteration can't produce the program", loop.Outputs)
+	}
+
+	return loop, nil
+}
+
+// SolveQuine returns the lowest value of register A, for which the program
+// outputs itself. It doesn't assume the shape of the puzzle input: the loop
+// over A and the number of bits of A every iteration drops are detected in
+// the program, which is then solved a few bits of A at a time, starting from
+// the last iteration. Programs without such a loop are brute forced up to a
+// limit.
+func SolveQuine(vm *VM) (int, error) {
+	err := errors.New("program uses a custom instruction set")
+
+	if vm.InstructionSet == nil {
+		var loop Loop
+
+		loop, err = AnalyzeLoop(vm.Program)
+		if err == nil {
+			if a, ok := solveLoop(newQuineRunner(vm), loop, 0, loop.Outputs); ok {
+				return a, nil
+			}
+
+			err = fmt.Errorf("no A fits the loop, which shifts A by %d bits", loop.Shift)
+		}
+	}
+
+	if a, ok := searchQuine(vm, quineSearchLimit); ok {
+		return a, nil
+	}
+
+	return 0, fmt.Errorf("%w: %v, and no A below %d works", ErrNoQuine, err, quineSearchLimit)
+}
+
+// quineRunner runs the compiled program for different values of A, starting
+// from the same state of the other registers.
+type quineRunner struct {
+	program  []int
+	compiled *Compiled
+	initial  Machine
+	output   []int
+}
+
+func newQuineRunner(vm *VM) *quineRunner {
+	return &quineRunner{
+		program:  vm.Program,
+		compiled: vm.Compile(),
+		initial:  vm.Machine,
+	}
+}
+
+// outputs reports whether the program outputs want with the given value of A.
+func (q *quineRunner) outputs(a int, want []int) bool {
+	m := q.initial
+	m.RegisterA = a
+	m.Output = q.output[:0]
+
+	ok, err := q.compiled.Outputs(&m, want)
+	q.output = m.Output
+
+	return ok && err == nil
+}
+
+// solveLoop builds A from the most significant bits, so that the last
+// iterations of the loop output the suffix of the program of the given length.
+func solveLoop(q *quineRunner, loop Loop, prefix int, length int) (int, bool) {
+	for j := range 1 << loop.Shift {
+		a := prefix<<loop.Shift + j
+
+		if !q.outputs(a, q.program[len(q.program)-length:]) {
+			continue
+		}
+
+		if length == len(q.program) {
+			return a, true
+		}
+
+		if res, ok := solveLoop(q, loop, a, length+loop.Outputs); ok {
+			return res, true
+		}
+	}
+
+	return 0, false
+}
+
+// searchQuine tries every value of A below the limit. Programs which don't
+// halt quickly are cut short by a step limit proportional to their length.
+func searchQuine(vm *VM, limit int) (int, bool) {
+	limited := *vm
+	limited.MaxSteps = 64 * len(vm.Program)
+
+	q := newQuineRunner(&limited)
+
+	for a := range limit {
+		if q.outputs(a, vm.Program) {
+			return a, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,163 @@
+package day17
+
+// Values of bits during the search. A bit is unknown, when it depends on
+// variables, which are not assigned yet.
+const (
+	valueFalse int8 = iota
+	valueTrue
+	valueUnknown
+)
+
+// solver searches for an assignment of variables satisfying every
+// constraint, by backtracking over variables from the most significant bit of
+// A. Zeros are tried first, so the first satisfying assignment is the lowest
+// value of A.
+type solver struct {
+	circuit     *Circuit
+	constraints []Bit
+	assignment  []int8
+
+	// memo caches values of gates for the current assignment, and is valid
+	// when the generation of a gate matches the current one
+	memo       []int8
+	generation []uint32
+	current    uint32
+}
+
+// Minimize returns the lowest value of A below 2 to the power of width, for
+// which every constraint holds.
+func (c *Circuit) Minimize(width int, constraints []Bit) (int, bool) {
+	s := &solver{
+		circuit:     c,
+		constraints: constraints,
+		assignment:  make([]int8, width),
+		memo:        make([]int8, len(c.gates)),
+		generation:  make([]uint32, len(c.gates)),
+	}
+
+	for i := range s.assignment {
+		s.assignment[i] = valueUnknown
+	}
+
+	if !s.search(width - 1) {
+		return 0, false
+	}
+
+	a := 0
+	for i, v := range s.assignment {
+		if v == valueTrue {
+			a |= 1 << i
+		}
+	}
+
+	return a, true
+}
+
+func (s *solver) search(variable int) bool {
+	if !s.consistent() {
+		return false
+	}
+
+	if variable < 0 {
+		return true
+	}
+
+	for _, v := range []int8{valueFalse, valueTrue} {
+		s.assignment[variable] = v
+
+		if s.search(variable - 1) {
+			return true
+		}
+	}
+
+	s.assignment[variable] = valueUnknown
+
+	return false
+}
+
+// consistent reports whether no constraint is known to be false under the
+// partial assignment.
+func (s *solver) consistent() bool {
+	s.current++
+
+	for _, b := range s.constraints {
+		if s.eval(b) == valueFalse {
+			return false
+		}
+	}
+
+	return true
+}
+
+// eval computes the value of a bit in three-valued logic.
+func (s *solver) eval(b Bit) int8 {
+	if s.generation[b] == s.current {
+		return s.memo[b]
+	}
+
+	g := s.circuit.gates[b]
+
+	var res int8
+
+	switch g.kind {
+	case gateConst:
+		res = int8(g.variable)
+	case gateVar:
+		res = valueFalse
+		if g.variable < len(s.assignment) {
+			res = s.assignment[g.variable]
+		}
+	case gateNot:
+		res = s.eval(g.a)
+		if res != valueUnknown {
+			res ^= 1
+		}
+	case gateAnd:
+		x, y := s.eval(g.a), s.eval(g.b)
+
+		switch {
+		case x == valueFalse || y == valueFalse:
+			res = valueFalse
+		case x == valueTrue && y == valueTrue:
+			res = valueTrue
+		default:
+			res = valueUnknown
+		}
+	case gateOr:
+		x, y := s.eval(g.a), s.eval(g.b)
+
+		switch {
+		case x == valueTrue || y == valueTrue:
+			res = valueTrue
+		case x == valueFalse && y == valueFalse:
+			res = valueFalse
+		default:
+			res = valueUnknown
+		}
+	case gateXor:
+		x, y := s.eval(g.a), s.eval(g.b)
+
+		res = valueUnknown
+		if x != valueUnknown && y != valueUnknown {
+			res = x ^ y
+		}
+	case gateIte:
+		switch s.eval(g.a) {
+		case valueTrue:
+			res = s.eval(g.b)
+		case valueFalse:
+			res = s.eval(g.c)
+		default:
+			if then, els := s.eval(g.b), s.eval(g.c); then == els {
+				res = then
+			} else {
+				res = valueUnknown
+			}
+		}
+	}
+
+	s.memo[b] = res
+	s.generation[b] = s.current
+
+	return res
+}
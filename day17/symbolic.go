@@ -0,0 +1,298 @@
+package day17
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(17, registry.Tool{
+		Name:        "symbolic",
+		Description: "print outputs of the program as formulas of bits of A, and the lowest A outputting the program",
+		Run:         symbolicTool,
+	})
+}
+
+// Path is a way through the program for some values of register A, which
+// are described by the conditions of the path.
+type Path struct {
+	Conditions []Bit
+	Output     []Word
+}
+
+type symbolicState struct {
+	ip      int
+	a, b, c Word
+	steps   int
+	path    Path
+}
+
+// SymbolicPaths executes the program with register A of the given width
+// holding variables of the circuit, while B and C hold their values. Jumps
+// conditioned on A fork the execution, and every path, which halts with at
+// most maxOutputs outputs, is yielded. Paths ending with a fault are yielded
+// with the fault, which any value of A taking the path would cause.
+func (vm *VM) SymbolicPaths(circuit *Circuit, width, maxOutputs int) iter.Seq2[Path, error] {
+	return func(yield func(Path, error) bool) {
+		if vm.InstructionSet != nil {
+			yield(Path{}, errors.New("symbolic execution supports only the default instruction set"))
+			return
+		}
+
+		for name, value := range map[string]int{"B": vm.RegisterB, "C": vm.RegisterC} {
+			if value < 0 || width < 63 && value >= 1<<width {
+				yield(Path{}, fmt.Errorf("register %s=%d doesn't fit into %d bits", name, value, width))
+				return
+			}
+		}
+
+		a := make(Word, width)
+		for i := range a {
+			a[i] = circuit.Var(i)
+		}
+
+		stack := []symbolicState{{
+			a: a,
+			b: ConstWord(vm.RegisterB, width),
+			c: ConstWord(vm.RegisterC, width),
+		}}
+
+		maxSteps := vm.maxSteps()
+
+		for len(stack) > 0 {
+			state := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			forked, err := vm.runSymbolic(circuit, &state, maxSteps, maxOutputs)
+			if forked != nil {
+				stack = append(stack, *forked, state)
+				continue
+			}
+
+			switch {
+			case err != nil:
+				if !yield(state.path, err) {
+					return
+				}
+			case state.ip >= len(vm.Program) && len(state.path.Output) <= maxOutputs:
+				if !yield(state.path, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// runSymbolic executes instructions until the path halts, faults, outputs
+// too much, or reaches a jump depending on A. In the latter case the state
+// takes the jump, and the state that doesn't is returned.
+func (vm *VM) runSymbolic(circuit *Circuit, s *symbolicState, maxSteps, maxOutputs int) (*symbolicState, error) {
+	combo := func(operand int) (Word, error) {
+		switch operand {
+		case 4:
+			return s.a, nil
+		case 5:
+			return s.b, nil
+		case 6:
+			return s.c, nil
+		case 7:
+			return nil, reservedComboError(operand)
+		}
+
+		return ConstWord(operand, len(s.a)), nil
+	}
+
+	for s.ip < len(vm.Program) {
+		if len(s.path.Output) > maxOutputs {
+			// the path is abandoned
+			s.ip = len(vm.Program)
+			return nil, nil
+		}
+
+		if s.steps == maxSteps {
+			return nil, &Fault{IP: s.ip, Err: ErrStepLimit}
+		}
+
+		if s.ip < 0 || s.ip+1 >= len(vm.Program) {
+			return nil, &Fault{IP: s.ip, Err: ErrTruncatedProgram}
+		}
+
+		opcode, operand := vm.Program[s.ip], vm.Program[s.ip+1]
+
+		if opcode < 0 || opcode >= len(DefaultInstructionSet) {
+			return nil, &Fault{IP: s.ip, Err: fmt.Errorf("%w %d", ErrInvalidOpcode, opcode)}
+		}
+
+		if operand < 0 || operand > 7 {
+			return nil, &Fault{IP: s.ip, Err: fmt.Errorf("%w %d", ErrInvalidOperand, operand)}
+		}
+
+		s.steps++
+		next := s.ip + 2
+
+		switch opcode {
+		case 1:
+			s.b = circuit.xorWords(s.b, ConstWord(operand, len(s.b)))
+		case 3:
+			nonzero := circuit.orAll(s.a)
+
+			switch nonzero {
+			case True:
+				next = operand
+			case False:
+			default:
+				notTaken := *s
+				notTaken.ip = next
+				notTaken.path = s.path.with(circuit.Not(nonzero))
+
+				s.ip = operand
+				s.path = s.path.with(nonzero)
+
+				return &notTaken, nil
+			}
+		case 4:
+			s.b = circuit.xorWords(s.b, s.c)
+		default:
+			value, err := combo(operand)
+			if err != nil {
+				return nil, &Fault{IP: s.ip, Err: err}
+			}
+
+			switch opcode {
+			case 0:
+				s.a = circuit.shiftRight(s.a, value)
+			case 2:
+				s.b = low3(value)
+			case 5:
+				s.path.Output = append(slices.Clip(s.path.Output), low3(value)[:3])
+			case 6:
+				s.b = circuit.shiftRight(s.a, value)
+			case 7:
+				s.c = circuit.shiftRight(s.a, value)
+			}
+		}
+
+		s.ip = next
+	}
+
+	return nil, nil
+}
+
+// with returns a copy of the path with another condition, which doesn't share
+// memory with the original.
+func (p Path) with(cond Bit) Path {
+	return Path{
+		Conditions: append(slices.Clip(p.Conditions), cond),
+		Output:     slices.Clip(p.Output),
+	}
+}
+
+// SolveOutput returns the lowest value of register A, for which the program
+// outputs want. It executes the program symbolically, and searches for A
+// satisfying conditions of every path with the right output.
+func SolveOutput(vm *VM, want []int) (int, error) {
+	const width = 63
+
+	circuit := NewCircuit()
+
+	best, found := 0, false
+
+	for path, err := range vm.SymbolicPaths(circuit, width, len(want)) {
+		var fault *Fault
+		if errors.As(err, &fault) {
+			// values of A taking the path make the program fault
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+
+		if len(path.Output) != len(want) {
+			continue
+		}
+
+		constraints := slices.Clone(path.Conditions)
+		for i, value := range path.Output {
+			for j, b := range value {
+				if want[i]>>j&1 == 1 {
+					constraints = append(constraints, b)
+				} else {
+					constraints = append(constraints, circuit.Not(b))
+				}
+			}
+		}
+
+		if a, ok := circuit.Minimize(width, constraints); ok && (!found || a < best) {
+			best, found = a, true
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("no value of A outputs %v", want)
+	}
+
+	return best, nil
+}
+
+func symbolicTool(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("symbolic", flag.ContinueOnError)
+	outputs := fs.Int("outputs", 0, "print paths with the given number of outputs (default the length of the program)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	vm, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	if *outputs == 0 {
+		*outputs = len(vm.Program)
+	}
+
+	circuit := NewCircuit()
+
+	for path, err := range vm.SymbolicPaths(circuit, 63, *outputs) {
+		if err != nil {
+			fmt.Fprintf(out, "path faults: %v\n", err)
+			continue
+		}
+
+		if len(path.Output) != *outputs {
+			continue
+		}
+
+		fmt.Fprintln(out, "path:")
+
+		for _, cond := range path.Conditions {
+			fmt.Fprintf(out, "  if %s\n", circuit.Format(cond))
+		}
+
+		for i, value := range path.Output {
+			for j, b := range value {
+				fmt.Fprintf(out, "  out[%d] bit %d = %s\n", i, j, circuit.Format(b))
+			}
+		}
+	}
+
+	a, err := SolveOutput(vm, vm.Program)
+	if err != nil {
+		return fmt.Errorf("solve: %w", err)
+	}
+
+	program := make([]string, len(vm.Program))
+	for i, num := range vm.Program {
+		program[i] = strconv.Itoa(num)
+	}
+
+	_, err = fmt.Fprintf(out, "lowest A outputting %s: %d\n", strings.Join(program, ","), a)
+	return err
+}
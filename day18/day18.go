@@ -0,0 +1,107 @@
+package day18
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/harmlessevil/advent-of-code-2024/graph/search"
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(18, registry.Part(Part1), registry.Part(Part2))
+}
+
+const simulationSteps = 1024
+const gridSize = 70
+
+func Part1(r io.Reader) (int, error) {
+	return part1(r, gridSize, simulationSteps)
+}
+
+func part1(r io.Reader, size, fallen int) (int, error) {
+	positions, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	corrupted := grid.New[bool](size+1, size+1)
+	for _, position := range positions[:fallen] {
+		*corrupted.At(position) = true
+	}
+
+	steps := shortestPath(corrupted)
+
+	return steps, nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	return part2(r, gridSize)
+}
+
+func part2(r io.Reader, size int) (string, error) {
+	positions, err := readInput(r)
+	if err != nil {
+		return "", fmt.Errorf("readInput: %w", err)
+	}
+
+	pos := findPositionThatDisconnectsGraph(grid.New[bool](size+1, size+1), positions)
+
+	return fmt.Sprintf("%d,%d", pos.Row, pos.Col), nil
+}
+
+func findPositionThatDisconnectsGraph(corrupted grid.Grid[bool], positions []grid.Vec2D) grid.Vec2D {
+	for _, position := range positions {
+		*corrupted.At(position) = true
+
+		if shortestPath(corrupted) == -1 {
+			return position
+		}
+	}
+
+	panic("unreachable")
+}
+
+// shortestPath returns the number of steps from the top left to the bottom
+// right corner of the memory space, or -1 if the exit is unreachable.
+func shortestPath(corrupted grid.Grid[bool]) int {
+	exit := grid.Vec2D{Row: corrupted.Rows() - 1, Col: corrupted.Cols() - 1}
+
+	steps := func(pos grid.Vec2D) iter.Seq2[grid.Vec2D, int] {
+		return func(yield func(grid.Vec2D, int) bool) {
+			for next := range corrupted.Neighbours4(pos) {
+				if !corrupted.Get(next) && !yield(next, 1) {
+					return
+				}
+			}
+		}
+	}
+
+	_, paths, ok := search.AStar(grid.Vec2D{}, steps, exit.ManhattanDistance, func(pos grid.Vec2D) bool {
+		return pos == exit
+	})
+	if !ok {
+		return -1
+	}
+
+	return paths.Dist[exit]
+}
+
+func readInput(r io.Reader) ([]grid.Vec2D, error) {
+	var positions []grid.Vec2D
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var pos grid.Vec2D
+		if _, err := fmt.Sscanf(scanner.Text(), "%d,%d", &pos.Row, &pos.Col); err != nil {
+			return nil, fmt.Errorf("parse line: %w", err)
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions, scanner.Err()
+}
@@ -0,0 +1,58 @@
+package day18
+
+import (
+	"io"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+const example = `5,4
+4,2
+4,5
+3,0
+2,1
+6,3
+2,4
+1,5
+0,6
+3,3
+2,6
+5,1
+1,2
+5,5
+2,5
+6,5
+1,4
+0,4
+6,4
+1,1
+6,1
+1,0
+0,5
+1,6
+2,0`
+
+func TestExamples(t *testing.T) {
+	// The example memory space is 7x7, and only 12 bytes fall in part 1.
+	t.Run("part1", func(t *testing.T) {
+		aoctest.Check(t, registry.Part(func(r io.Reader) (int, error) {
+			return part1(r, 6, 12)
+		}), example, "22")
+	})
+
+	t.Run("part2", func(t *testing.T) {
+		aoctest.Check(t, registry.Part(func(r io.Reader) (string, error) {
+			return part2(r, 6)
+		}), example, "6,1")
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 18)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 18)
+}
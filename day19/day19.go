@@ -1,29 +1,21 @@
-package main
+package day19
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"slices"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(19, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	available, query, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	available, query, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -42,8 +34,8 @@ func part1() (int, error) {
 	return total, nil
 }
 
-func part2() (int, error) {
-	available, query, err := readInput()
+func Part2(r io.Reader) (int, error) {
+	available, query, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -95,14 +87,8 @@ func memoize[T comparable, R any](f func(T) R) func(T) R {
 	}
 }
 
-func readInput() ([]string, []string, error) {
-	f, err := os.Open("input/day-19.txt")
-	if err != nil {
-		return nil, nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
+func readInput(r io.Reader) ([]string, []string, error) {
+	scanner := bufio.NewScanner(r)
 
 	if !scanner.Scan() {
 		return nil, nil, scanner.Err()
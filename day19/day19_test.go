@@ -0,0 +1,32 @@
+package day19
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `r, wr, b, g, bwu, rb, gb, br
+
+brwrr
+bggr
+gbbr
+rrbgbr
+ubwu
+bwurrg
+brgr
+bbrgwb`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 19, []aoctest.Example{
+		{Input: example, Part1: "6", Part2: "16"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 19)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 19)
+}
@@ -0,0 +1,198 @@
+package day20
+
+import (
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/harmlessevil/advent-of-code-2024/graph/search"
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(20, registry.Part(Part1), registry.Part(Part2))
+}
+
+const minTimeSaved = 100
+
+func Part1(r io.Reader) (int, error) {
+	return part1(r, minTimeSaved)
+}
+
+func part1(r io.Reader, minTimeSaved int) (int, error) {
+	m, start, end, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	track := raceTrack(m, start, end)
+
+	fastestTime := track[end]
+
+	cheats := findCheats(m, track, fastestTime)
+
+	counts := map[int]int{}
+	for _, seconds := range cheats {
+		counts[fastestTime-seconds]++
+	}
+
+	total := 0
+	for amount, count := range counts {
+		if amount >= minTimeSaved {
+			total += count
+		}
+	}
+
+	return total, nil
+}
+
+func Part2(r io.Reader) (int, error) {
+	return part2(r, minTimeSaved)
+}
+
+func part2(r io.Reader, minTimeSaved int) (int, error) {
+	m, start, end, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	track := raceTrack(m, start, end)
+
+	total := 0
+
+	visited := map[grid.Vec2D]struct{}{}
+	cheats := map[LongCheat]struct{}{}
+
+	pos := start
+	for picoseconds := 0; pos != end; picoseconds++ {
+		visited[pos] = struct{}{}
+
+		for dRow := -20; dRow <= 20; dRow++ {
+			for dCol := -20; dCol <= 20; dCol++ {
+				next := pos.Add(grid.Dir2D{Row: dRow, Col: dCol})
+				if !m.InBounds(next) {
+					continue
+				}
+
+				if m.Get(next) == '#' {
+					continue
+				}
+
+				dist := pos.ManhattanDistance(next)
+
+				if dist == 0 || dist > 20 {
+					continue
+				}
+
+				cheat := LongCheat{Start: pos, End: next}
+				if _, ok := cheats[cheat]; ok {
+					continue
+				}
+
+				timeSaved := track[next] - picoseconds - dist
+				cheats[cheat] = struct{}{}
+
+				if timeSaved >= minTimeSaved {
+					total++
+				}
+			}
+		}
+
+		for _, dir := range []grid.Dir2D{grid.DirectionUp, grid.DirectionDown, grid.DirectionLeft, grid.DirectionRight} {
+			next := pos.Add(dir)
+
+			if !m.InBounds(next) {
+				continue
+			}
+
+			if m.Get(next) == '#' {
+				continue
+			}
+
+			if _, ok := visited[next]; ok {
+				continue
+			}
+
+			pos = next
+			break
+		}
+	}
+
+	return total, nil
+}
+
+type LongCheat struct {
+	Start grid.Vec2D
+	End   grid.Vec2D
+}
+
+type Cheat struct {
+	Start     grid.Vec2D
+	Direction grid.Dir2D
+}
+
+// raceTrack returns time it takes to reach every tile of the track from the
+// start without cheating.
+func raceTrack(m grid.Grid[byte], start, end grid.Vec2D) map[grid.Vec2D]int {
+	moves := func(pos grid.Vec2D) iter.Seq[grid.Vec2D] {
+		return func(yield func(grid.Vec2D) bool) {
+			for next := range m.Neighbours4(pos) {
+				if m.Get(next) != '#' && !yield(next) {
+					return
+				}
+			}
+		}
+	}
+
+	_, paths, ok := search.BFS(start, moves, func(pos grid.Vec2D) bool {
+		return pos == end
+	})
+	if !ok {
+		panic("unreachable")
+	}
+
+	track := map[grid.Vec2D]int{}
+	for picoseconds, pos := range paths.Path(end) {
+		track[pos] = picoseconds
+	}
+
+	return track
+}
+
+// findCheats tries to pass through a single wall from every tile of the
+// track, and returns time it takes to finish the race with every cheat that
+// leads back onto the track.
+func findCheats(m grid.Grid[byte], track map[grid.Vec2D]int, fastestTime int) map[Cheat]int {
+	cheats := map[Cheat]int{}
+
+	for pos, picoseconds := range track {
+		for _, dir := range grid.Directions4 {
+			cheatStart := pos.Add(dir)
+			if !m.InBounds(cheatStart) || m.Get(cheatStart) != '#' {
+				continue
+			}
+
+			cheatEnd, ok := track[cheatStart.Add(dir)]
+			if !ok {
+				continue
+			}
+
+			cheats[Cheat{Start: cheatStart, Direction: dir}] = picoseconds + 2 + fastestTime - cheatEnd
+		}
+	}
+
+	return cheats
+}
+
+func readInput(r io.Reader) (grid.Grid[byte], grid.Vec2D, grid.Vec2D, error) {
+	m, err := grid.Parse(r)
+	if err != nil {
+		return nil, grid.Vec2D{}, grid.Vec2D{}, fmt.Errorf("parse grid: %w", err)
+	}
+
+	start, _ := grid.Find(m, 'S')
+	end, _ := grid.Find(m, 'E')
+
+	return m, start, end, nil
+}
@@ -0,0 +1,55 @@
+package day20
+
+import (
+	"io"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+const example = `###############
+#...#...#.....#
+#.#.#.#.#.###.#
+#S#...#.#.#...#
+#######.#.#.###
+#######.#.#...#
+#######.#.###.#
+###..E#...#...#
+###.#######.###
+#...###...#...#
+#.#####.#.###.#
+#.#...#.#.#...#
+#.#.#.#.#.#.###
+#...#...#...###
+###############`
+
+func TestExamples(t *testing.T) {
+	// No cheat in the example saves 100 picoseconds, so answers are checked
+	// against the tallies of cheats listed in the puzzle statement.
+	for _, tc := range []struct {
+		name         string
+		part         func(io.Reader, int) (int, error)
+		minTimeSaved int
+		want         string
+	}{
+		{name: "part1/20", part: part1, minTimeSaved: 20, want: "5"},
+		{name: "part1/2", part: part1, minTimeSaved: 2, want: "44"},
+		{name: "part2/76", part: part2, minTimeSaved: 76, want: "3"},
+		{name: "part2/50", part: part2, minTimeSaved: 50, want: "285"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			aoctest.Check(t, registry.Part(func(r io.Reader) (int, error) {
+				return tc.part(r, tc.minTimeSaved)
+			}), example, tc.want)
+		})
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 20)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 20)
+}
@@ -1,30 +1,23 @@
-package main
+package day21
 
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"iter"
-	"os"
 	"slices"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/grid"
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(21, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	codes, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	codes, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -61,10 +54,10 @@ func part1() (int, error) {
 	return total, nil
 }
 
-func part2() (int, error) {
+func Part2(r io.Reader) (int, error) {
 	getMinSeqLengthMemoized = memoize3(getMinSeqLength)
 
-	codes, err := readInput()
+	codes, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
@@ -130,7 +123,7 @@ func getMinSeqLength(keypadIndex int, from, to byte) int {
 
 var getMinSeqLengthMemoized func(keypadIndex int, from byte, to byte) int
 
-var press = memoize3(func(keypadIndex int, pos, next Vec2D) []string {
+var press = memoize3(func(keypadIndex int, pos, next grid.Vec2D) []string {
 	var presses []byte
 
 	start := pos
@@ -226,8 +219,8 @@ func cartesian[T any](m [][]T) iter.Seq[[]T] {
 	}
 }
 
-func isValid(keypadIndex int, start Vec2D, perm []byte) bool {
-	var f func(Vec2D) bool
+func isValid(keypadIndex int, start grid.Vec2D, perm []byte) bool {
+	var f func(grid.Vec2D) bool
 	if keypadIndex == 0 {
 		f = isValidNumeric
 	} else {
@@ -278,18 +271,7 @@ func permutations[T any](s []T) iter.Seq[[]T] {
 	}
 }
 
-type Vec2D struct {
-	Row int
-	Col int
-}
-
-func (v Vec2D) Add(d Dir2D) Vec2D {
-	return Vec2D{Row: v.Row + d.Row, Col: v.Col + d.Col}
-}
-
-type Dir2D Vec2D
-
-var numericKeypad = map[byte]Vec2D{
+var numericKeypad = map[byte]grid.Vec2D{
 	'7': {Row: 0, Col: 0},
 	'8': {Row: 0, Col: 1},
 	'9': {Row: 0, Col: 2},
@@ -303,15 +285,15 @@ var numericKeypad = map[byte]Vec2D{
 	'A': {Row: 3, Col: 2},
 }
 
-func isValidNumeric(pos Vec2D) bool {
-	if pos == (Vec2D{Row: 3, Col: 0}) {
+func isValidNumeric(pos grid.Vec2D) bool {
+	if pos == (grid.Vec2D{Row: 3, Col: 0}) {
 		return false
 	}
 
 	return pos.Row >= 0 && pos.Row < 4 && pos.Col >= 0 && pos.Col < 3
 }
 
-var directionalKeypad = map[byte]Vec2D{
+var directionalKeypad = map[byte]grid.Vec2D{
 	'^': {Row: 0, Col: 1},
 	'A': {Row: 0, Col: 2},
 	'<': {Row: 1, Col: 0},
@@ -319,15 +301,15 @@ var directionalKeypad = map[byte]Vec2D{
 	'>': {Row: 1, Col: 2},
 }
 
-func isValidDirectional(pos Vec2D) bool {
-	if pos == (Vec2D{}) {
+func isValidDirectional(pos grid.Vec2D) bool {
+	if pos == (grid.Vec2D{}) {
 		return false
 	}
 
 	return pos.Row >= 0 && pos.Row < 2 && pos.Col >= 0 && pos.Col < 3
 }
 
-var keyToDirection = map[byte]Dir2D{
+var keyToDirection = map[byte]grid.Dir2D{
 	'^': {Row: -1, Col: 0},
 	'<': {Row: 0, Col: -1},
 	'v': {Row: 1, Col: 0},
@@ -356,16 +338,10 @@ func memoize3[P1, P2, P3 comparable, R any](f func(P1, P2, P3) R) func(P1, P2, P
 	}
 }
 
-func readInput() ([]string, error) {
-	f, err := os.Open("input/day-21.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([]string, error) {
 	var codes []string
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		codes = append(codes, scanner.Text())
 	}
@@ -0,0 +1,27 @@
+package day21
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `029A
+980A
+179A
+456A
+379A`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 21, []aoctest.Example{
+		{Input: example, Part1: "126384"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 21)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 21)
+}
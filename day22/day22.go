@@ -0,0 +1,187 @@
+package day22
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(22, registry.Part(Part1), registry.Part(Part2))
+}
+
+const steps = 2_000
+
+func Part1(r io.Reader) (int, error) {
+	return Part1Workers(r, runtime.GOMAXPROCS(0))
+}
+
+// Part1Workers solves part 1 with buyers simulated by the given number of
+// goroutines.
+func Part1Workers(r io.Reader, workers int) (int, error) {
+	buyers, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	return sumSecrets(buyers, workers), nil
+}
+
+// sumSecrets sums the last secret numbers of every buyer.
+func sumSecrets(buyers []int, workers int) int {
+	sums := parallel(buyers, workers, func(buyers []int) int {
+		total := 0
+		for _, secret := range buyers {
+			for range steps {
+				secret = next(secret)
+			}
+
+			total += secret
+		}
+
+		return total
+	})
+
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+
+	return total
+}
+
+const sequenceLength = 4
+
+// windows is the number of sequences of changes, each of which is one of 19
+// changes from -9 to 9.
+const windows = 19 * 19 * 19 * 19
+
+func Part2(r io.Reader) (int, error) {
+	return Part2Workers(r, runtime.GOMAXPROCS(0))
+}
+
+// Part2Workers solves part 2 with buyers simulated by the given number of
+// goroutines.
+func Part2Workers(r io.Reader, workers int) (int, error) {
+	buyers, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	return mostBananas(buyers, workers), nil
+}
+
+// mostBananas sums prices, at which every buyer sells after each sequence of
+// changes, and returns the best sum. Sequences are numbers in base 19 indexing
+// a flat array. Every worker sums prices of its buyers in an array of its
+// own, and the arrays are added up once the workers are done.
+func mostBananas(buyers []int, workers int) int {
+	shards := parallel(buyers, workers, func(buyers []int) []int {
+		totals := make([]int, windows)
+
+		// seen holds the last buyer, who sold after the sequence, numbered
+		// from 1
+		seen := make([]int32, windows)
+
+		for i, secret := range buyers {
+			sell(secret, int32(i+1), totals, seen)
+		}
+
+		return totals
+	})
+
+	totals := shards[0]
+	for _, shard := range shards[1:] {
+		for i, bananas := range shard {
+			totals[i] += bananas
+		}
+	}
+
+	return slices.Max(totals)
+}
+
+// sell adds the price, at which the buyer sells after every sequence of
+// changes, to totals. The buyer sells the first time the sequence occurs,
+// which is when its stamp isn't in seen yet.
+func sell(secret int, stamp int32, totals []int, seen []int32) {
+	price := secret % 10
+	window := 0
+
+	for j := range steps {
+		secret = next(secret)
+		nextPrice := secret % 10
+
+		// the oldest change drops out of the window
+		window = (window*19 + nextPrice - price + 9) % windows
+
+		if j >= sequenceLength-1 && seen[window] != stamp {
+			seen[window] = stamp
+			totals[window] += nextPrice
+		}
+
+		price = nextPrice
+	}
+}
+
+// parallel splits buyers into contiguous shards, one per worker, and returns
+// results of work on every shard. There is at least one worker, and no more
+// than buyers.
+func parallel[T any](buyers []int, workers int, work func(buyers []int) T) []T {
+	workers = max(1, min(workers, len(buyers)))
+
+	res := make([]T, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			res[w] = work(buyers[w*len(buyers)/workers : (w+1)*len(buyers)/workers])
+		}()
+	}
+
+	wg.Wait()
+
+	return res
+}
+
+func next(num int) int {
+	res := prune(mix(num*64, num))
+	res = prune(mix(res/32, res))
+	res = prune(mix(res*2048, res))
+
+	return res
+}
+
+func mix(a, b int) int {
+	return a ^ b
+}
+
+func prune(a int) int {
+	return a % 16777216
+}
+
+func readInput(r io.Reader) ([]int, error) {
+	var buyers []int
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		str := scanner.Text()
+
+		num, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, fmt.Errorf("parse number: %w", err)
+		}
+
+		buyers = append(buyers, num)
+	}
+
+	return buyers, scanner.Err()
+}
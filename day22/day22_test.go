@@ -0,0 +1,208 @@
+package day22
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example1 = `1
+10
+100
+2024`
+
+const example2 = `1
+2
+3
+2024`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 22, []aoctest.Example{
+		{Input: example1, Part1: "37327623"},
+		{Input: example2, Part2: "23"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 22)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 22)
+}
+
+type indexedSequence struct {
+	index    int
+	sequence sequence
+}
+
+type sequence [sequenceLength]int
+
+// mostBananasMaps is the straightforward solution to part 2, which finds
+// sequences of every buyer with maps, and sums prices for every sequence.
+func mostBananasMaps(buyers []int) int {
+	changes := make([][]int, len(buyers))
+	prices := make([][]int, len(buyers))
+	sequences := make([]map[sequence]int, len(buyers))
+	indexToSeq := make([][]indexedSequence, len(buyers))
+
+	for i, secret := range buyers {
+		changes[i] = make([]int, steps)
+		prices[i] = make([]int, steps)
+		sequences[i] = map[sequence]int{}
+
+		price := secret % 10
+
+		for j := range steps {
+			secret = next(secret)
+
+			nextPrice := secret % 10
+			prices[i][j] = nextPrice
+			changes[i][j] = nextPrice - price
+
+			if j > 2 {
+				seq := sequence(changes[i][j-3 : j+1])
+				if _, ok := sequences[i][seq]; !ok {
+					sequences[i][seq] = j
+					indexToSeq[i] = append(indexToSeq[i], indexedSequence{index: j, sequence: seq})
+				}
+			}
+
+			price = nextPrice
+		}
+	}
+
+	mostBananas := 0
+	usedSequences := map[sequence]struct{}{}
+	for _, indexedSequences := range indexToSeq {
+		for _, seq := range indexedSequences {
+			if _, ok := usedSequences[seq.sequence]; ok {
+				continue
+			}
+			usedSequences[seq.sequence] = struct{}{}
+
+			bananas := 0
+			for buyer, m := range sequences {
+				idx, ok := m[seq.sequence]
+				if !ok {
+					continue
+				}
+
+				bananas += prices[buyer][idx]
+			}
+
+			mostBananas = max(mostBananas, bananas)
+		}
+	}
+
+	return mostBananas
+}
+
+func TestMostBananas(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+
+	for _, n := range []int{1, 10, 200} {
+		buyers := make([]int, n)
+		for i := range buyers {
+			buyers[i] = r.Intn(1 << 24)
+		}
+
+		if got, want := mostBananas(buyers, 1), mostBananasMaps(buyers); got != want {
+			t.Errorf("%d buyers: got %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+
+	buyers := make([]int, 500)
+	for i := range buyers {
+		buyers[i] = r.Intn(1 << 24)
+	}
+
+	wantSum, wantBananas := sumSecrets(buyers, 1), mostBananas(buyers, 1)
+
+	var input strings.Builder
+	for _, secret := range buyers {
+		fmt.Fprintln(&input, secret)
+	}
+
+	for _, workers := range []int{0, 2, 3, 8, 1000} {
+		if got := sumSecrets(buyers, workers); got != wantSum {
+			t.Errorf("%d workers: got sum %d, want %d", workers, got, wantSum)
+		}
+
+		if got := mostBananas(buyers, workers); got != wantBananas {
+			t.Errorf("%d workers: got %d bananas, want %d", workers, got, wantBananas)
+		}
+
+		if got, err := Part1Workers(strings.NewReader(input.String()), workers); err != nil || got != wantSum {
+			t.Errorf("Part1Workers with %d workers: got %d (%v), want %d", workers, got, err, wantSum)
+		}
+
+		if got, err := Part2Workers(strings.NewReader(input.String()), workers); err != nil || got != wantBananas {
+			t.Errorf("Part2Workers with %d workers: got %d (%v), want %d", workers, got, err, wantBananas)
+		}
+	}
+}
+
+func TestJumpAhead(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+
+	for range 20 {
+		secret := r.Intn(1 << 24)
+
+		want := secret
+		for n := range steps + 1 {
+			if n == 0 || n == 1 || n == 7 || n == steps {
+				got, err := JumpAhead(secret, n)
+				if err != nil {
+					t.Fatalf("JumpAhead: %v", err)
+				}
+
+				if got != want {
+					t.Fatalf("%d after %d steps: got %d, want %d", secret, n, got, want)
+				}
+			}
+
+			want = next(want)
+		}
+	}
+
+	// the top bit of the input doesn't matter after a step
+	if got, _ := JumpAhead(1<<24|123, 1); got != next(1<<24|123) {
+		t.Errorf("got %d, want %d", got, next(1<<24|123))
+	}
+
+	if _, err := JumpAhead(123, -1); err == nil {
+		t.Error("expected an error for a negative number of steps")
+	}
+}
+
+func TestCycleLength(t *testing.T) {
+	order, err := Order()
+	if err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+
+	if order != 1<<24-1 {
+		t.Errorf("got order %d, want %d", order, 1<<24-1)
+	}
+
+	for secret, want := range map[int]int{0: 1, 1: order, 123: order} {
+		if got := CycleLength(secret, order); got != want {
+			t.Errorf("%d: got cycle of %d, want %d", secret, got, want)
+		}
+	}
+
+	// far jumps wrap around the cycle
+	const far = 1_000_000_000_000
+	got, _ := JumpAhead(123, far)
+	if want, _ := JumpAhead(123, far%order); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,197 @@
+package day22
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(22, registry.Tool{
+		Name:        "cycles",
+		Description: "print cycle lengths of secret numbers of buyers, and secrets after any number of steps",
+		Run:         cyclesTool,
+	})
+}
+
+const (
+	secretBits = 24
+	secretMask = 1<<secretBits - 1
+)
+
+// Matrix is a linear map of secret numbers over GF(2): XOR of secrets maps to
+// XOR of their images. It is given by images of single bits.
+type Matrix [secretBits]int
+
+// NextMatrix computes the next secret number, since shifts, mixing and
+// pruning are all linear maps.
+var NextMatrix = matrixOf(next)
+
+func matrixOf(f func(int) int) Matrix {
+	var m Matrix
+	for i := range m {
+		m[i] = f(1 << i)
+	}
+
+	return m
+}
+
+func Identity() Matrix {
+	return matrixOf(func(x int) int { return x })
+}
+
+// Apply maps the lower bits of x.
+func (m Matrix) Apply(x int) int {
+	res := 0
+	for i, image := range m {
+		if x>>i&1 == 1 {
+			res ^= image
+		}
+	}
+
+	return res
+}
+
+// Mul returns the map, which applies other and then m.
+func (m Matrix) Mul(other Matrix) Matrix {
+	var res Matrix
+	for i, image := range other {
+		res[i] = m.Apply(image)
+	}
+
+	return res
+}
+
+// Pow applies the map n times, which takes O(log n) multiplications. It
+// panics, if n is negative.
+func (m Matrix) Pow(n int) Matrix {
+	if n < 0 {
+		panic(fmt.Sprintf("negative power %d", n))
+	}
+
+	res := Identity()
+
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			res = res.Mul(m)
+		}
+
+		m = m.Mul(m)
+	}
+
+	return res
+}
+
+// JumpAhead returns the secret number after n steps.
+func JumpAhead(secret, n int) (int, error) {
+	switch {
+	case n < 0:
+		return 0, fmt.Errorf("negative number of steps %d", n)
+	case n == 0:
+		return secret, nil
+	}
+
+	// bits above the pruned ones don't affect the next number
+	return NextMatrix.Pow(n).Apply(secret & secretMask), nil
+}
+
+// Order returns the number of steps, after which every secret number repeats.
+// It is searched among divisors of 2^24 - 1, which is checked to be a
+// multiple of it.
+func Order() (int, error) {
+	if NextMatrix.Pow(secretMask) != Identity() {
+		return 0, fmt.Errorf("the order of the map doesn't divide %d", secretMask)
+	}
+
+	return period(secretMask, func(k int) bool {
+		return NextMatrix.Pow(k) == Identity()
+	}), nil
+}
+
+// CycleLength returns the number of steps, after which the secret number
+// repeats, given the order of the map. Bits above the pruned ones are
+// ignored.
+func CycleLength(secret, order int) int {
+	secret &= secretMask
+
+	return period(order, func(k int) bool {
+		return NextMatrix.Pow(k).Apply(secret) == secret
+	})
+}
+
+// period returns the least k dividing n, for which repeats(k) holds, given
+// that repeats(n) does, and that k holds exactly for multiples of the least
+// one.
+func period(n int, repeats func(k int) bool) int {
+	for _, p := range primeFactors(n) {
+		for n%p == 0 && repeats(n/p) {
+			n /= p
+		}
+	}
+
+	return n
+}
+
+// primeFactors returns distinct prime factors of n.
+func primeFactors(n int) []int {
+	var res []int
+
+	for p := 2; p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+
+		res = append(res, p)
+		for n%p == 0 {
+			n /= p
+		}
+	}
+
+	if n > 1 {
+		res = append(res, n)
+	}
+
+	return res
+}
+
+func cyclesTool(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("cycles", flag.ContinueOnError)
+	n := fs.Int("steps", steps, "print secret numbers after the given number of steps")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *n < 0 {
+		return fmt.Errorf("negative number of steps %d", *n)
+	}
+
+	buyers, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	order, err := Order()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "every secret number repeats after %d steps\n", order)
+
+	jump := NextMatrix.Pow(*n)
+
+	for _, secret := range buyers {
+		length := CycleLength(secret, order)
+
+		after := secret
+		if *n > 0 {
+			after = jump.Apply(secret & secretMask)
+		}
+
+		fmt.Fprintf(out, "%d: cycle of %d, after %d steps %d\n", secret, length, *n, after)
+	}
+
+	return nil
+}
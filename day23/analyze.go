@@ -0,0 +1,327 @@
+package day23
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(23, registry.Tool{
+		Name:        "analyze",
+		Description: "print components, degrees, clustering, articulation points and bridges of the network",
+		Run:         analyzeTool,
+	})
+}
+
+// DegreeCount is the number of nodes of some degree.
+type DegreeCount struct {
+	Degree int `json:"degree"`
+	Nodes  int `json:"nodes"`
+}
+
+// Report describes the structure of the network.
+type Report struct {
+	Nodes int `json:"nodes"`
+	Edges int `json:"edges"`
+
+	// Components are sorted from the largest, and nodes within them by name.
+	Components [][]string    `json:"components"`
+	Degrees    []DegreeCount `json:"degrees"`
+
+	// AverageClustering is the mean over nodes of the share of pairs of
+	// neighbours, which are joined themselves. Nodes with fewer than two
+	// neighbours count as zero.
+	AverageClustering float64 `json:"average_clustering"`
+
+	// Transitivity is the share of paths of two edges, which are closed into
+	// triangles.
+	Transitivity float64 `json:"transitivity"`
+
+	// ArticulationPoints and Bridges are nodes and edges, removing which
+	// splits their component.
+	ArticulationPoints []string    `json:"articulation_points"`
+	Bridges            [][2]string `json:"bridges"`
+}
+
+// Analyze computes the report of the graph built by readInput.
+func Analyze(m map[string]map[string]struct{}) Report {
+	g := NewGraph(m)
+
+	report := Report{
+		Nodes:              len(g.Names),
+		Components:         [][]string{},
+		Degrees:            []DegreeCount{},
+		ArticulationPoints: []string{},
+		Bridges:            [][2]string{},
+	}
+
+	degrees := map[int]int{}
+	for _, adj := range g.Adj {
+		report.Edges += len(adj)
+		degrees[len(adj)]++
+	}
+
+	report.Edges /= 2
+
+	for degree, nodes := range degrees {
+		report.Degrees = append(report.Degrees, DegreeCount{Degree: degree, Nodes: nodes})
+	}
+
+	slices.SortFunc(report.Degrees, func(a, b DegreeCount) int {
+		return cmp.Compare(a.Degree, b.Degree)
+	})
+
+	report.AverageClustering, report.Transitivity = g.clustering()
+
+	for _, component := range g.components() {
+		names := make([]string, len(component))
+		for i, v := range component {
+			names[i] = g.Names[v]
+		}
+
+		slices.Sort(names)
+		report.Components = append(report.Components, names)
+	}
+
+	slices.SortStableFunc(report.Components, func(a, b []string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+
+	points, bridges := g.cutVertices()
+
+	for _, v := range points {
+		report.ArticulationPoints = append(report.ArticulationPoints, g.Names[v])
+	}
+
+	for _, e := range bridges {
+		report.Bridges = append(report.Bridges, [2]string{g.Names[e[0]], g.Names[e[1]]})
+	}
+
+	return report
+}
+
+// clustering returns the average local clustering coefficient and the
+// transitivity of the graph.
+func (g *Graph) clustering() (float64, float64) {
+	neighbour := make([]bool, len(g.Adj))
+
+	var sum float64
+	var links, pairs int
+
+	for _, adj := range g.Adj {
+		if len(adj) < 2 {
+			continue
+		}
+
+		for _, u := range adj {
+			neighbour[u] = true
+		}
+
+		// every link between neighbours is seen from both ends
+		n := 0
+		for _, u := range adj {
+			for _, w := range g.Adj[u] {
+				if neighbour[w] {
+					n++
+				}
+			}
+		}
+
+		n /= 2
+
+		for _, u := range adj {
+			neighbour[u] = false
+		}
+
+		p := len(adj) * (len(adj) - 1) / 2
+
+		sum += float64(n) / float64(p)
+		links += n
+		pairs += p
+	}
+
+	if pairs == 0 {
+		return 0, 0
+	}
+
+	return sum / float64(len(g.Adj)), float64(links) / float64(pairs)
+}
+
+// components returns nodes of every connected component, in the order they
+// are reached from the lowest node of the component.
+func (g *Graph) components() [][]int {
+	seen := make([]bool, len(g.Adj))
+
+	var res [][]int
+
+	for start := range g.Adj {
+		if seen[start] {
+			continue
+		}
+
+		seen[start] = true
+		component := []int{start}
+
+		for i := 0; i < len(component); i++ {
+			for _, u := range g.Adj[component[i]] {
+				if !seen[u] {
+					seen[u] = true
+					component = append(component, u)
+				}
+			}
+		}
+
+		res = append(res, component)
+	}
+
+	return res
+}
+
+// cutVertices returns articulation points and bridges of the graph, found by
+// Tarjan's algorithm: an edge from v down to u of the DFS tree is a bridge,
+// when nothing under u reaches v or above by a back edge, and v is an
+// articulation point, when nothing under u reaches above v.
+func (g *Graph) cutVertices() ([]int, [][2]int) {
+	// entered holds times of entering nodes, and low the earliest time
+	// reachable from the subtree of a node by one back edge
+	entered := make([]int, len(g.Adj))
+	low := make([]int, len(g.Adj))
+	for i := range entered {
+		entered[i] = -1
+	}
+
+	isPoint := make([]bool, len(g.Adj))
+
+	var bridges [][2]int
+
+	time := 0
+
+	var visit func(v, parent int)
+	visit = func(v, parent int) {
+		entered[v], low[v] = time, time
+		time++
+
+		children := 0
+
+		for _, u := range g.Adj[v] {
+			if u == parent {
+				continue
+			}
+
+			if entered[u] >= 0 {
+				low[v] = min(low[v], entered[u])
+				continue
+			}
+
+			children++
+			visit(u, v)
+			low[v] = min(low[v], low[u])
+
+			if low[u] > entered[v] {
+				bridges = append(bridges, [2]int{min(u, v), max(u, v)})
+			}
+
+			if parent >= 0 && low[u] >= entered[v] {
+				isPoint[v] = true
+			}
+		}
+
+		// the root splits its component, when it has several subtrees
+		if parent < 0 && children > 1 {
+			isPoint[v] = true
+		}
+	}
+
+	for v := range g.Adj {
+		if entered[v] < 0 {
+			visit(v, -1)
+		}
+	}
+
+	var points []int
+	for v, ok := range isPoint {
+		if ok {
+			points = append(points, v)
+		}
+	}
+
+	slices.SortFunc(bridges, func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+
+	return points, bridges
+}
+
+// WriteText writes the report for reading.
+func (r Report) WriteText(w io.Writer) error {
+	sizes := make([]string, len(r.Components))
+	for i, component := range r.Components {
+		sizes[i] = fmt.Sprint(len(component))
+	}
+
+	fmt.Fprintf(w, "nodes: %d\n", r.Nodes)
+	fmt.Fprintf(w, "edges: %d\n", r.Edges)
+	fmt.Fprintf(w, "components: %d, of sizes %s\n", len(r.Components), strings.Join(sizes, ", "))
+
+	fmt.Fprintln(w, "degrees:")
+	for _, d := range r.Degrees {
+		fmt.Fprintf(w, "  %d: %d nodes\n", d.Degree, d.Nodes)
+	}
+
+	fmt.Fprintf(w, "average clustering: %.4f\n", r.AverageClustering)
+	fmt.Fprintf(w, "transitivity: %.4f\n", r.Transitivity)
+
+	points := "none"
+	if len(r.ArticulationPoints) > 0 {
+		points = strings.Join(r.ArticulationPoints, ",")
+	}
+
+	fmt.Fprintf(w, "articulation points: %s\n", points)
+
+	bridges := make([]string, len(r.Bridges))
+	for i, b := range r.Bridges {
+		bridges[i] = b[0] + "-" + b[1]
+	}
+
+	if len(bridges) == 0 {
+		bridges = []string{"none"}
+	}
+
+	_, err := fmt.Fprintf(w, "bridges: %s\n", strings.Join(bridges, ","))
+	return err
+}
+
+func analyzeTool(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format, text or json")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	report := Analyze(m)
+
+	switch *format {
+	case "text":
+		return report.WriteText(out)
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(report)
+	}
+
+	return fmt.Errorf("unknown format %q", *format)
+}
@@ -0,0 +1,290 @@
+package day23
+
+import (
+	"iter"
+	"maps"
+	"math/bits"
+	"slices"
+)
+
+// bitset is a set of small integers.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) unset(i int) {
+	b[i/64] &^= 1 << (i % 64)
+}
+
+func (b bitset) empty() bool {
+	for _, w := range b {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b bitset) and(other bitset) bitset {
+	res := make(bitset, len(b))
+	for i := range b {
+		res[i] = b[i] & other[i]
+	}
+
+	return res
+}
+
+func (b bitset) andNot(other bitset) bitset {
+	res := make(bitset, len(b))
+	for i := range b {
+		res[i] = b[i] &^ other[i]
+	}
+
+	return res
+}
+
+func (b bitset) or(other bitset) bitset {
+	res := make(bitset, len(b))
+	for i := range b {
+		res[i] = b[i] | other[i]
+	}
+
+	return res
+}
+
+// intersectionSize returns the number of elements in both sets.
+func (b bitset) intersectionSize(other bitset) int {
+	n := 0
+	for i := range b {
+		n += bits.OnesCount64(b[i] & other[i])
+	}
+
+	return n
+}
+
+// all yields elements of the set in ascending order.
+func (b bitset) all() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b {
+			for w != 0 {
+				if !yield(i*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Graph is an undirected graph over nodes numbered in the order of their
+// names.
+type Graph struct {
+	Names []string
+	Adj   [][]int
+}
+
+// NewGraph numbers nodes of the adjacency map built by readInput.
+func NewGraph(m map[string]map[string]struct{}) *Graph {
+	g := &Graph{Names: slices.Sorted(maps.Keys(m))}
+
+	ids := make(map[string]int, len(g.Names))
+	for id, name := range g.Names {
+		ids[name] = id
+	}
+
+	g.Adj = make([][]int, len(g.Names))
+	for id, name := range g.Names {
+		for neighbour := range m[name] {
+			g.Adj[id] = append(g.Adj[id], ids[neighbour])
+		}
+
+		slices.Sort(g.Adj[id])
+	}
+
+	return g
+}
+
+// DegeneracyOrder returns nodes in the order of removing a node of the least
+// degree from the rest of the graph, so that every node has at most as many
+// neighbours later in the order as the degeneracy of the graph.
+func (g *Graph) DegeneracyOrder() []int {
+	degree := make([]int, len(g.Adj))
+	maxDegree := 0
+
+	for v, adj := range g.Adj {
+		degree[v] = len(adj)
+		maxDegree = max(maxDegree, degree[v])
+	}
+
+	// buckets of nodes by their degree; nodes are removed lazily, when their
+	// degree is out of date
+	buckets := make([][]int, maxDegree+1)
+	for v, d := range degree {
+		buckets[d] = append(buckets[d], v)
+	}
+
+	removed := make([]bool, len(g.Adj))
+	order := make([]int, 0, len(g.Adj))
+
+	for d := 0; len(order) < len(g.Adj); {
+		if len(buckets[d]) == 0 {
+			d++
+			continue
+		}
+
+		v := buckets[d][len(buckets[d])-1]
+		buckets[d] = buckets[d][:len(buckets[d])-1]
+
+		if removed[v] || degree[v] != d {
+			continue
+		}
+
+		removed[v] = true
+		order = append(order, v)
+
+		for _, u := range g.Adj[v] {
+			if !removed[u] {
+				degree[u]--
+				buckets[degree[u]] = append(buckets[degree[u]], u)
+			}
+		}
+
+		// a neighbour may have dropped into a lower bucket
+		d = max(d-1, 0)
+	}
+
+	return order
+}
+
+// MaximalCliques enumerates maximal cliques with the Bron–Kerbosch algorithm
+// with Tomita pivoting. The outer level goes over nodes in degeneracy order,
+// and searches for cliques among neighbours of each node, which are numbered
+// anew, so that sets are bitsets no wider than the degeneracy allows. This is
+// what lets the search handle sparse graphs with tens of thousands of nodes.
+//
+// Source: Eppstein, Löffler, Strash, "Listing All Maximal Cliques in Sparse
+// Graphs in Near-optimal Time"
+func (g *Graph) MaximalCliques() iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		order := g.DegeneracyOrder()
+
+		position := make([]int, len(order))
+		for i, v := range order {
+			position[v] = i
+		}
+
+		// local holds local numbers of neighbours of the current node
+		local := make([]int, len(g.Adj))
+		for i := range local {
+			local[i] = -1
+		}
+
+		for _, v := range order {
+			neighbours := g.Adj[v]
+
+			for i, u := range neighbours {
+				local[u] = i
+			}
+
+			adj := make([]bitset, len(neighbours))
+			p, x := newBitset(len(neighbours)), newBitset(len(neighbours))
+
+			for i, u := range neighbours {
+				adj[i] = newBitset(len(neighbours))
+				for _, w := range g.Adj[u] {
+					if local[w] >= 0 {
+						adj[i].set(local[w])
+					}
+				}
+
+				if position[u] > position[v] {
+					p.set(i)
+				} else {
+					x.set(i)
+				}
+			}
+
+			for _, u := range neighbours {
+				local[u] = -1
+			}
+
+			s := pivotSearch{adj: adj, yield: func(clique []int) bool {
+				res := make([]int, 0, len(clique)+1)
+				res = append(res, v)
+				for _, i := range clique {
+					res = append(res, neighbours[i])
+				}
+
+				slices.Sort(res)
+				return yield(res)
+			}}
+
+			if !s.expand(nil, p, x) {
+				return
+			}
+		}
+	}
+}
+
+type pivotSearch struct {
+	adj   []bitset
+	yield func([]int) bool
+}
+
+// expand reports maximal cliques extending r by nodes of p, which don't
+// extend to any node of x. Only nodes not adjacent to the pivot are tried,
+// since any maximal clique contains the pivot or some node not adjacent to it.
+func (s *pivotSearch) expand(r []int, p, x bitset) bool {
+	if p.empty() {
+		if x.empty() {
+			return s.yield(r)
+		}
+
+		return true
+	}
+
+	pivot, best := -1, -1
+	for u := range p.or(x).all() {
+		if n := p.intersectionSize(s.adj[u]); n > best {
+			pivot, best = u, n
+		}
+	}
+
+	for v := range p.andNot(s.adj[pivot]).all() {
+		if !s.expand(append(r, v), p.and(s.adj[v]), x.and(s.adj[v])) {
+			return false
+		}
+
+		p.unset(v)
+		x.set(v)
+	}
+
+	return true
+}
+
+// BronKerboschPivot enumerates the same maximal cliques as BronKerbosch with
+// Graph.MaximalCliques.
+func BronKerboschPivot(m map[string]map[string]struct{}) iter.Seq[map[string]struct{}] {
+	return func(yield func(map[string]struct{}) bool) {
+		g := NewGraph(m)
+
+		for clique := range g.MaximalCliques() {
+			res := make(map[string]struct{}, len(clique))
+			for _, v := range clique {
+				res[g.Names[v]] = struct{}{}
+			}
+
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
@@ -1,73 +1,43 @@
-package main
+package day23
 
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"iter"
 	"maps"
-	"os"
 	"slices"
 	"strings"
-)
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
 
-	if res, err := part2(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(23, registry.Part(Part1), registry.Part(Part2))
 }
 
-func part1() (int, error) {
-	m, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	m, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("readInput: %w", err)
 	}
 
-	triangles := map[[3]string]struct{}{}
-	for from, edges := range m {
-		for to := range edges {
-			for computer := range m {
-				if _, ok := m[to][computer]; !ok {
-					continue
-				}
-
-				if _, ok := m[computer][from]; !ok {
-					continue
-				}
-
-				triangle := [3]string{from, to, computer}
-				slices.Sort(triangle[:])
-
-				triangles[triangle] = struct{}{}
-			}
-		}
-	}
-
 	count := 0
-	for triangle := range triangles {
-		if triangle[0][0] == 't' || triangle[1][0] == 't' || triangle[2][0] == 't' {
-			count++
-		}
+	for range KCliques(m, 3, func(computer string) bool { return computer[0] == 't' }) {
+		count++
 	}
 
 	return count, nil
 }
 
-func part2() (string, error) {
-	m, err := readInput()
+func Part2(r io.Reader) (string, error) {
+	m, err := readInput(r)
 	if err != nil {
 		return "", fmt.Errorf("readInput: %w", err)
 	}
 
 	var largestClique map[string]struct{}
-	for clique := range BronKerbosch(m) {
+	for clique := range BronKerboschPivot(m) {
 		if len(clique) > len(largestClique) {
 			largestClique = clique
 		}
@@ -76,6 +46,51 @@ func part2() (string, error) {
 	return strings.Join(slices.Sorted(maps.Keys(largestClique)), ","), nil
 }
 
+// KCliques yields cliques of k nodes, in which any node passes the filter, as
+// sorted lists of nodes. A nil filter passes every clique. Cliques are built
+// from nodes in ascending order, so that each is yielded once.
+func KCliques(m map[string]map[string]struct{}, k int, filter func(string) bool) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		if k < 1 {
+			return
+		}
+
+		// extend tries nodes of candidates, which are adjacent to every node
+		// of the clique and follow them, in turn
+		var extend func(clique, candidates []string, passed bool) bool
+		extend = func(clique, candidates []string, passed bool) bool {
+			if len(clique) == k {
+				if !passed {
+					return true
+				}
+
+				return yield(slices.Clone(clique))
+			}
+
+			for i, v := range candidates {
+				var next []string
+				for _, u := range candidates[i+1:] {
+					if _, ok := m[v][u]; ok {
+						next = append(next, u)
+					}
+				}
+
+				if len(clique)+1+len(next) < k {
+					continue
+				}
+
+				if !extend(append(clique, v), next, passed || filter(v)) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		extend(nil, slices.Sorted(maps.Keys(m)), filter == nil)
+	}
+}
+
 // BronKerbosch is an enumeration algorithm for finding all maximal cliques in an undirected graph.
 //
 // Source: https://en.wikipedia.org/wiki/Bron–Kerbosch_algorithm
@@ -130,16 +145,10 @@ func BronKerbosch(m map[string]map[string]struct{}) iter.Seq[map[string]struct{}
 	}
 }
 
-func readInput() (map[string]map[string]struct{}, error) {
-	f, err := os.Open("input/day-23.txt")
-	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) (map[string]map[string]struct{}, error) {
 	m := map[string]map[string]struct{}{}
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		edge := scanner.Bytes()
 
@@ -0,0 +1,274 @@
+package day23
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `kh-tc
+qp-kh
+de-cg
+ka-co
+yn-aq
+qp-ub
+cg-tb
+vc-aq
+tb-ka
+wh-tc
+yn-cg
+kh-ub
+ta-co
+de-co
+tc-td
+tb-wq
+wh-td
+ta-ka
+td-qp
+aq-cg
+wq-ub
+ub-vc
+de-ta
+wq-aq
+wq-vc
+wh-yn
+ka-de
+kh-ta
+co-tc
+wh-qp
+tb-vc
+td-yn`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 23, []aoctest.Example{
+		{Input: example, Part1: "7", Part2: "co,de,ka,ta"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 23)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 23)
+}
+
+// randomGraph returns a graph of n nodes, in which every node is joined to
+// degree random others.
+func randomGraph(r *rand.Rand, n, degree int) map[string]map[string]struct{} {
+	m := map[string]map[string]struct{}{}
+	for i := range n {
+		m[fmt.Sprintf("n%d", i)] = map[string]struct{}{}
+	}
+
+	for i := range n {
+		for range degree {
+			j := r.Intn(n)
+			if i == j {
+				continue
+			}
+
+			from, to := fmt.Sprintf("n%d", i), fmt.Sprintf("n%d", j)
+			m[from][to] = struct{}{}
+			m[to][from] = struct{}{}
+		}
+	}
+
+	return m
+}
+
+func sortedCliques(seq iter.Seq[map[string]struct{}]) []string {
+	var res []string
+	for clique := range seq {
+		res = append(res, strings.Join(slices.Sorted(maps.Keys(clique)), ","))
+	}
+
+	slices.Sort(res)
+	return res
+}
+
+func TestBronKerboschPivot(t *testing.T) {
+	m, err := readInput(strings.NewReader(example))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	graphs := []map[string]map[string]struct{}{m}
+
+	r := rand.New(rand.NewSource(23))
+	for _, degree := range []int{1, 3, 10, 30} {
+		graphs = append(graphs, randomGraph(r, 100, degree))
+	}
+
+	for i, m := range graphs {
+		got := sortedCliques(BronKerboschPivot(m))
+		want := sortedCliques(BronKerbosch(m))
+
+		if !slices.Equal(got, want) {
+			t.Errorf("graph %d: got %d cliques, want %d", i, len(got), len(want))
+		}
+	}
+}
+
+func TestDegeneracyOrder(t *testing.T) {
+	g := NewGraph(randomGraph(rand.New(rand.NewSource(23)), 1000, 5))
+
+	order := g.DegeneracyOrder()
+	if len(order) != len(g.Names) {
+		t.Fatalf("got %d nodes, want %d", len(order), len(g.Names))
+	}
+
+	// degrees of nodes among the ones not removed yet
+	degree := make([]int, len(g.Adj))
+	for v, adj := range g.Adj {
+		degree[v] = len(adj)
+	}
+
+	removed := make([]bool, len(g.Adj))
+
+	for _, v := range order {
+		if removed[v] {
+			t.Fatalf("node %d is removed twice", v)
+		}
+
+		for u := range g.Adj {
+			if !removed[u] && degree[u] < degree[v] {
+				t.Fatalf("node %d of degree %d is removed before node %d of degree %d", v, degree[v], u, degree[u])
+			}
+		}
+
+		removed[v] = true
+		for _, u := range g.Adj[v] {
+			degree[u]--
+		}
+	}
+}
+
+func BenchmarkMaximalCliques(b *testing.B) {
+	g := NewGraph(randomGraph(rand.New(rand.NewSource(23)), 20000, 10))
+
+	b.ResetTimer()
+
+	for range b.N {
+		for range g.MaximalCliques() {
+		}
+	}
+}
+
+func TestKCliques(t *testing.T) {
+	m, err := readInput(strings.NewReader(example))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	var got []string
+	for clique := range KCliques(m, 4, func(computer string) bool { return computer == "co" }) {
+		got = append(got, strings.Join(clique, ","))
+	}
+
+	if want := []string{"co,de,ka,ta"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// every k-clique is a subset of a maximal clique
+	m = randomGraph(rand.New(rand.NewSource(23)), 60, 8)
+
+	for k := 1; k <= 5; k++ {
+		want := map[string]struct{}{}
+		for clique := range BronKerboschPivot(m) {
+			for subset := range subsets(slices.Sorted(maps.Keys(clique)), k) {
+				want[strings.Join(subset, ",")] = struct{}{}
+			}
+		}
+
+		got := map[string]struct{}{}
+		for clique := range KCliques(m, k, nil) {
+			key := strings.Join(clique, ",")
+			if _, ok := got[key]; ok {
+				t.Errorf("k=%d: %s is yielded twice", k, key)
+			}
+
+			got[key] = struct{}{}
+		}
+
+		if !maps.Equal(got, want) {
+			t.Errorf("k=%d: got %d cliques, want %d", k, len(got), len(want))
+		}
+	}
+}
+
+// subsets yields every subset of k elements of the sorted slice.
+func subsets(s []string, k int) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		var pick func(start int, subset []string) bool
+		pick = func(start int, subset []string) bool {
+			if len(subset) == k {
+				return yield(subset)
+			}
+
+			for i := start; i < len(s); i++ {
+				if !pick(i+1, append(subset, s[i])) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		pick(0, nil)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	// two triangles joined by a bridge, and a separate edge
+	m, err := readInput(strings.NewReader(`aa-ab
+ab-ac
+ac-aa
+ac-ad
+ad-ae
+ae-af
+af-ad
+ba-bb`))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	got := Analyze(m)
+
+	want := Report{
+		Nodes:              8,
+		Edges:              8,
+		Components:         [][]string{{"aa", "ab", "ac", "ad", "ae", "af"}, {"ba", "bb"}},
+		Degrees:            []DegreeCount{{1, 2}, {2, 4}, {3, 2}},
+		AverageClustering:  (4 + 2.0/3) / 8,
+		Transitivity:       6.0 / 10,
+		ArticulationPoints: []string{"ac", "ad"},
+		Bridges:            [][2]string{{"ac", "ad"}, {"ba", "bb"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	var sb strings.Builder
+	if err := analyzeTool([]string{"--format", "json"}, strings.NewReader(example), &sb); err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+
+	if decoded.Nodes != 16 || decoded.Edges != 32 || len(decoded.Components) != 1 {
+		t.Errorf("got %+v", decoded)
+	}
+}
@@ -0,0 +1,220 @@
+package day24
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.Register(24, registry.Part(Part1), registry.Part(Part2))
+}
+
+func Part1(r io.Reader) (int, error) {
+	wires, gates, err := readInput(r)
+	if err != nil {
+		return 0, fmt.Errorf("readInput: %w", err)
+	}
+
+	z := getWires(gates, 'z')
+
+	res, err := calculate(wires, gates, z)
+	if err != nil {
+		return 0, fmt.Errorf("calculate: %w", err)
+	}
+
+	return res, nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	_, gates, err := readInput(r)
+	if err != nil {
+		return "", fmt.Errorf("readInput: %w", err)
+	}
+
+	return strings.Join(AnalyzeAdder(gates).Suspects(), ","), nil
+}
+
+func calculate(wires map[string]int, gates map[string]Gate, z []string) (int, error) {
+	if err := evaluate(wires, gates, z); err != nil {
+		return 0, err
+	}
+
+	return toNumber(wires, z), nil
+}
+
+func toNumber(wires map[string]int, output []string) int {
+	result := 0
+
+	for i, wire := range output {
+		result += wires[wire] << i
+	}
+
+	return result
+}
+
+func getWires[T any](wires map[string]T, start byte) []string {
+	var res []string
+	for wire := range wires {
+		if wire[0] == start {
+			res = append(res, wire)
+		}
+	}
+
+	slices.Sort(res)
+	return res
+}
+
+var (
+	ErrUndefinedWire   = errors.New("undefined wire")
+	ErrUnknownOperator = errors.New("unknown operator")
+)
+
+// CycleError reports wires, which depend on themselves, in the order they
+// feed each other.
+type CycleError struct {
+	Wires []string
+}
+
+func (e *CycleError) Error() string {
+	return "cycle through " + strings.Join(append(slices.Clone(e.Wires), e.Wires[0]), " -> ")
+}
+
+// evaluate computes values of the output wires, and of every wire they depend
+// on, in topological order of the gates.
+func evaluate(wires map[string]int, gates map[string]Gate, output []string) error {
+	order, err := topologicalOrder(wires, gates, output)
+	if err != nil {
+		return err
+	}
+
+	var inputs []int
+
+	for _, wire := range order {
+		gate := gates[wire]
+
+		inputs = inputs[:0]
+		for _, in := range gate.Inputs {
+			inputs = append(inputs, wires[in])
+		}
+
+		wires[wire] = gate.Op.Eval(inputs...)
+	}
+
+	return nil
+}
+
+// topologicalOrder returns wires driven by gates, which the output depends on,
+// so that every wire comes after the inputs of its gate.
+func topologicalOrder(wires map[string]int, gates map[string]Gate, output []string) ([]string, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := map[string]int{}
+
+	var order []string
+
+	// path holds wires being visited, so that a cycle can be reported
+	var path []string
+
+	var visit func(wire, usedBy string) error
+	visit = func(wire, usedBy string) error {
+		if _, ok := wires[wire]; ok {
+			return nil
+		}
+
+		switch state[wire] {
+		case visiting:
+			// the path goes from gates to their inputs, against the signal
+			cycle := slices.Clone(path[slices.Index(path, wire):])
+			slices.Reverse(cycle)
+
+			// start from the lowest wire, so that the same cycle is always
+			// reported the same way
+			first := slices.Index(cycle, slices.Min(cycle))
+
+			return &CycleError{Wires: append(cycle[first:], cycle[:first]...)}
+		case visited:
+			return nil
+		}
+
+		gate, ok := gates[wire]
+		if !ok {
+			if usedBy == "" {
+				return fmt.Errorf("%w %s", ErrUndefinedWire, wire)
+			}
+
+			return fmt.Errorf("%w %s, used by the gate driving %s", ErrUndefinedWire, wire, usedBy)
+		}
+
+		state[wire] = visiting
+		path = append(path, wire)
+
+		for _, input := range gate.Inputs {
+			if err := visit(input, wire); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[wire] = visited
+		order = append(order, wire)
+
+		return nil
+	}
+
+	for _, wire := range output {
+		if err := visit(wire, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+func readInput(r io.Reader) (map[string]int, map[string]Gate, error) {
+	scanner := bufio.NewScanner(r)
+
+	wires := map[string]int{}
+	for scanner.Scan() {
+		var wire string
+		var value int
+
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		if _, err := fmt.Sscanf(line, "%3s: %d", &wire, &value); err != nil {
+			return nil, nil, fmt.Errorf("parse wire: %w", err)
+		}
+
+		wires[wire] = value
+	}
+
+	gates := map[string]Gate{}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[len(fields)-2] != "->" {
+			return nil, nil, fmt.Errorf("parse gate: no output in %q", scanner.Text())
+		}
+
+		out := fields[len(fields)-1]
+
+		gate, err := parseGate(fields[:len(fields)-2])
+		if err != nil {
+			return nil, nil, fmt.Errorf("gate driving %s: %w", out, err)
+		}
+
+		gates[out] = gate
+	}
+
+	return wires, gates, scanner.Err()
+}
@@ -0,0 +1,568 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `x00: 1
+x01: 1
+x02: 1
+y00: 0
+y01: 1
+y02: 0
+
+x00 AND y00 -> z00
+x01 XOR y01 -> z01
+x02 OR y02 -> z02`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 24, []aoctest.Example{
+		{Input: example, Part1: "4"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 24)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 24)
+}
+
+func TestCalculateErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "cycle",
+			input: `x00: 1
+
+x00 AND abc -> z00
+z00 OR def -> ghi
+ghi XOR x00 -> abc
+x00 OR x00 -> def`,
+			want: "cycle through abc -> z00 -> ghi -> abc",
+		},
+		{
+			name: "undefined wire",
+			input: `x00: 1
+
+x00 AND y00 -> z00`,
+			want: "undefined wire y00, used by the gate driving z00",
+		},
+		{
+			name: "unknown operator",
+			input: `x00: 1
+
+x00 IMPLY x00 -> z00`,
+			want: `gate driving z00: unknown operator "IMPLY"`,
+		},
+		{
+			name: "too many inputs",
+			input: `x00: 1
+
+NOT x00 x00 -> z00`,
+			want: "gate driving z00: wrong number of inputs: NOT takes 1, got 2",
+		},
+		{
+			name: "too few inputs",
+			input: `x00: 1
+
+AND x00 -> z00`,
+			want: "gate driving z00: wrong number of inputs: AND takes at least 2, got 1",
+		},
+		{
+			name: "mixed operators",
+			input: `x00: 1
+
+x00 AND x00 OR x00 -> z00`,
+			want: "gate driving z00: operators AND and OR are mixed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Part1(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !strings.HasSuffix(err.Error(), tt.want) {
+				t.Errorf("got %q, want it to end with %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycleError(t *testing.T) {
+	_, gates, err := readInput(strings.NewReader(`x00: 1
+
+x00 AND b -> a
+a OR x00 -> b
+a XOR x00 -> z00`))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	var cycle *CycleError
+	if err := evaluate(map[string]int{"x00": 1}, gates, []string{"z00"}); !errors.As(err, &cycle) {
+		t.Fatalf("got %v, want CycleError", err)
+	}
+
+	if !slices.Equal(cycle.Wires, []string{"a", "b"}) {
+		t.Errorf("got %v, want [a b]", cycle.Wires)
+	}
+}
+
+// rippleCarryAdder returns a netlist of an adder of numbers with the given
+// width, built the same way as puzzle inputs.
+func rippleCarryAdder(width int) string {
+	var sb strings.Builder
+
+	for i := range width {
+		fmt.Fprintf(&sb, "x%02d: 0\n", i)
+	}
+
+	for i := range width {
+		fmt.Fprintf(&sb, "y%02d: 0\n", i)
+	}
+
+	sb.WriteString("\n")
+
+	fmt.Fprintln(&sb, "x00 XOR y00 -> z00")
+	fmt.Fprintln(&sb, "x00 AND y00 -> c00")
+
+	for i := 1; i < width; i++ {
+		fmt.Fprintf(&sb, "x%02d XOR y%02d -> s%02d\n", i, i, i)
+		fmt.Fprintf(&sb, "x%02d AND y%02d -> a%02d\n", i, i, i)
+		fmt.Fprintf(&sb, "s%02d XOR c%02d -> z%02d\n", i, i-1, i)
+		fmt.Fprintf(&sb, "s%02d AND c%02d -> p%02d\n", i, i-1, i)
+
+		carry := fmt.Sprintf("c%02d", i)
+		if i == width-1 {
+			carry = fmt.Sprintf("z%02d", width)
+		}
+
+		fmt.Fprintf(&sb, "a%02d OR p%02d -> %s\n", i, i, carry)
+	}
+
+	return sb.String()
+}
+
+func TestSimulatorMatchesEvaluate(t *testing.T) {
+	wires, gates, err := readInput(strings.NewReader(rippleCarryAdder(8)))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	inputs := append(getWires(wires, 'x'), getWires(wires, 'y')...)
+	z := getWires(gates, 'z')
+
+	sim, err := NewSimulator(gates, inputs, z)
+	if err != nil {
+		t.Fatalf("NewSimulator: %v", err)
+	}
+
+	r := rand.New(rand.NewSource(24))
+
+	lanes := make([]uint64, len(inputs))
+	for i := range lanes {
+		lanes[i] = r.Uint64()
+	}
+
+	outputs := sim.Run(lanes)
+
+	for lane := range 64 {
+		values := map[string]int{}
+		for i, wire := range inputs {
+			values[wire] = int(lanes[i] >> lane & 1)
+		}
+
+		if err := evaluate(values, gates, z); err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+
+		for i, wire := range z {
+			if got := int(outputs[i] >> lane & 1); got != values[wire] {
+				t.Fatalf("lane %d, wire %s: got %d, want %d", lane, wire, got, values[wire])
+			}
+		}
+	}
+}
+
+func TestAdderVerify(t *testing.T) {
+	netlist := rippleCarryAdder(44)
+
+	_, gates, err := readInput(strings.NewReader(netlist))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	adder, err := NewAdder(gates)
+	if err != nil {
+		t.Fatalf("NewAdder: %v", err)
+	}
+
+	pairs := adder.TestPairs(rand.New(rand.NewSource(24)), 10000)
+
+	if p, ok := adder.Verify(pairs); !ok {
+		t.Fatalf("correct adder fails on %+v", p)
+	}
+
+	// outputs of the sum and the carry of a bit swapped
+	gates["z10"], gates["c10"] = gates["c10"], gates["z10"]
+
+	adder, err = NewAdder(gates)
+	if err != nil {
+		t.Fatalf("NewAdder: %v", err)
+	}
+
+	p, ok := adder.Verify(pairs)
+	if ok {
+		t.Fatal("broken adder passes")
+	}
+
+	if p.X+p.Y < 1<<10 {
+		t.Errorf("pair %+v doesn't reach the broken bit", p)
+	}
+}
+
+func TestFixAdder(t *testing.T) {
+	_, gates, err := readInput(strings.NewReader(rippleCarryAdder(44)))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	// the kinds of swaps found in puzzle inputs: a sum with a carry, a sum
+	// with an intermediate AND, and XOR with AND of the same inputs
+	broken := applySwaps(gates, []Swap{{"z07", "c07"}, {"z16", "p16"}, {"z25", "a25"}, {"s33", "a33"}})
+
+	var sb strings.Builder
+	sb.WriteString("x00: 0\ny00: 0\n\n")
+	for wire, gate := range broken {
+		fmt.Fprintf(&sb, "%s -> %s\n", gate, wire)
+	}
+
+	got, err := Part2(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("Part2: %v", err)
+	}
+
+	if want := "a25,a33,c07,p16,s33,z07,z16,z25"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// swapped carries are told apart only by their bits
+	broken = applySwaps(gates, []Swap{{"c10", "c20"}})
+
+	swaps, err := FixAdder(broken, AnalyzeAdder(broken).Suspects())
+	if err != nil {
+		t.Fatalf("FixAdder: %v", err)
+	}
+
+	if want := []Swap{{"c10", "c20"}}; !slices.Equal(swaps, want) {
+		t.Errorf("got %v, want %v", swaps, want)
+	}
+
+	if _, err := FixAdder(broken, nil); !errors.Is(err, ErrNoFix) {
+		t.Errorf("got %v, want ErrNoFix", err)
+	}
+}
+
+func TestPairings(t *testing.T) {
+	var got []string
+	for swaps := range pairings([]string{"a", "b", "c", "d"}) {
+		got = append(got, fmt.Sprint(swaps))
+	}
+
+	want := []string{"[[a b] [c d]]", "[[a c] [b d]]", "[[a d] [b c]]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	_, gates, err := readInput(strings.NewReader(`x00: 1
+y00: 0
+
+x00 XOR y00 -> z00
+x00 AND y00 -> z01`))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := WriteMermaid(&sb, gates, []string{"z01"}); err != nil {
+		t.Fatalf("WriteMermaid: %v", err)
+	}
+
+	want := `flowchart LR
+  subgraph bit00 [bit 0]
+    w_x00([x00])
+    w_y00([y00])
+    w_z00{"z00 XOR"}
+    w_z01["z01 AND"]
+  end
+  w_x00 --> w_z00
+  w_y00 --> w_z00
+  w_x00 --> w_z01
+  w_y00 --> w_z01
+  classDef AND fill:lightblue
+  class w_z01 AND
+  classDef XOR fill:gold
+  class w_z00 XOR
+  classDef suspect stroke:red,stroke-width:3px
+  class w_z01 suspect
+`
+	if got := sb.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteCyclic(t *testing.T) {
+	// end and abc feed each other, and z00 reads the cycle
+	_, gates, err := readInput(strings.NewReader(`x00: 1
+y00: 0
+
+x00 AND abc -> end
+end OR y00 -> abc
+end XOR y00 -> z00`))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := WriteMermaid(&sb, gates, []string{"end"}); err != nil {
+		t.Fatalf("WriteMermaid: %v", err)
+	}
+
+	got := sb.String()
+
+	for _, want := range []string{`  w_abc("abc OR")`, `  w_end["end AND"]`, "  w_end --> w_z00", "  class w_end suspect"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q is missing from:\n%s", want, got)
+		}
+	}
+
+	sb.Reset()
+	if err := WriteDOT(&sb, gates, nil); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	_, gates, err := readInput(strings.NewReader(rippleCarryAdder(4)))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := WriteDOT(&sb, gates, []string{"c02"}); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+
+	got := sb.String()
+
+	for _, want := range []string{
+		"subgraph cluster_bit03 {",
+		`z04 [label="z04\nOR", shape=ellipse, fillcolor=palegreen];`,
+		`c02 [label="c02\nOR", shape=ellipse, fillcolor=palegreen, color=red, penwidth=3];`,
+		"s03 -> z03;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q is missing from:\n%s", want, got)
+		}
+	}
+
+	// the carry out of the top bit belongs to it
+	bit3 := got[strings.Index(got, "cluster_bit03"):]
+	if !strings.Contains(bit3[:strings.Index(bit3, "}")], "z04 [") {
+		t.Errorf("z04 is not in bit 3:\n%s", got)
+	}
+}
+
+func TestParseGate(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		want  string
+	}{
+		{"x00 AND y00", "x00 AND y00"},
+		{"a XNOR b XNOR c", "a XNOR b XNOR c"},
+		{"NOR a b c", "a NOR b NOR c"},
+		{"NOT a", "NOT a"},
+	} {
+		gate, err := parseGate(strings.Fields(tt.input))
+		if err != nil {
+			t.Errorf("%s: %v", tt.input, err)
+			continue
+		}
+
+		if got := gate.String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+// rippleBorrowSubtractor returns a netlist, which subtracts y from x modulo
+// 2 to the power of width, by adding the complement of y and a carry of 1.
+func rippleBorrowSubtractor(width int) string {
+	var sb strings.Builder
+
+	sb.WriteString("x00: 0\ny00: 0\n\n")
+
+	fmt.Fprintln(&sb, "x00 XOR y00 -> z00")
+	fmt.Fprintln(&sb, "NOT y00 -> n00")
+	fmt.Fprintln(&sb, "x00 OR n00 -> c00")
+
+	for i := 1; i < width; i++ {
+		fmt.Fprintf(&sb, "NOT y%02d -> n%02d\n", i, i)
+		fmt.Fprintf(&sb, "x%02d XOR n%02d XOR c%02d -> z%02d\n", i, i, i-1, i)
+		fmt.Fprintf(&sb, "NAND x%02d n%02d -> p%02d\n", i, i, i)
+		fmt.Fprintf(&sb, "NAND x%02d c%02d -> q%02d\n", i, i-1, i)
+		fmt.Fprintf(&sb, "NAND n%02d c%02d -> r%02d\n", i, i-1, i)
+		fmt.Fprintf(&sb, "NAND p%02d q%02d r%02d -> c%02d\n", i, i, i, i)
+	}
+
+	return sb.String()
+}
+
+func TestSubtractor(t *testing.T) {
+	const width = 16
+
+	_, gates, err := readInput(strings.NewReader(rippleBorrowSubtractor(width)))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	x := make([]string, width)
+	y := make([]string, width)
+	for i := range width {
+		x[i] = fmt.Sprintf("x%02d", i)
+		y[i] = fmt.Sprintf("y%02d", i)
+	}
+
+	z := getWires(gates, 'z')
+
+	sim, err := NewSimulator(gates, slices.Concat(x, y), z)
+	if err != nil {
+		t.Fatalf("NewSimulator: %v", err)
+	}
+
+	r := rand.New(rand.NewSource(24))
+
+	lanes := make([]uint64, 2*width)
+	for i := range lanes {
+		lanes[i] = r.Uint64()
+	}
+
+	outputs := sim.Run(lanes)
+
+	for lane := range 64 {
+		values := map[string]int{}
+
+		var a, b int
+		for i := range width {
+			values[x[i]] = int(lanes[i] >> lane & 1)
+			values[y[i]] = int(lanes[width+i] >> lane & 1)
+			a |= values[x[i]] << i
+			b |= values[y[i]] << i
+		}
+
+		want := (a - b) & (1<<width - 1)
+
+		got, err := calculate(values, gates, z)
+		if err != nil {
+			t.Fatalf("calculate: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("evaluate: %d - %d = %d, want %d", a, b, got, want)
+		}
+
+		var simulated int
+		for i := range z {
+			simulated |= int(outputs[i]>>lane&1) << i
+		}
+
+		if simulated != want {
+			t.Errorf("simulator: %d - %d = %d, want %d", a, b, simulated, want)
+		}
+	}
+}
+
+func TestAnalyzeAdder(t *testing.T) {
+	for _, width := range []int{2, 3, 8, 44} {
+		_, gates, err := readInput(strings.NewReader(rippleCarryAdder(width)))
+		if err != nil {
+			t.Fatalf("readInput: %v", err)
+		}
+
+		a := AnalyzeAdder(gates)
+
+		if a.Width != width {
+			t.Errorf("width %d: got width %d", width, a.Width)
+		}
+
+		if len(a.Deviations) != 0 {
+			t.Errorf("width %d: got deviations %v", width, a.Deviations)
+		}
+
+		top := width - 1
+		for wire, want := range map[string]Label{
+			"z00":                       {RoleHalfSum, 0},
+			"c00":                       {RoleHalfCarry, 0},
+			"a01":                       {RoleInputAnd, 1},
+			fmt.Sprintf("s%02d", top):   {RoleInputXor, top},
+			fmt.Sprintf("p%02d", top):   {RoleCarryAnd, top},
+			fmt.Sprintf("z%02d", top):   {RoleSumXor, top},
+			fmt.Sprintf("z%02d", width): {RoleCarryOr, top},
+		} {
+			if got := a.Labels[wire]; got != want {
+				t.Errorf("width %d, %s: got %v, want %v", width, wire, got, want)
+			}
+		}
+	}
+}
+
+func TestAdderDeviations(t *testing.T) {
+	_, gates, err := readInput(strings.NewReader(rippleCarryAdder(8)))
+	if err != nil {
+		t.Fatalf("readInput: %v", err)
+	}
+
+	broken := applySwaps(gates, []Swap{{"z03", "c03"}, {"s05", "a05"}})
+	broken["n06"] = Gate{Op: OperationNot{}, Inputs: []string{"x06"}}
+	broken["m02"] = Gate{Op: OperationXor{}, Inputs: []string{"x02", "y03"}}
+
+	got := AnalyzeAdder(broken).Deviations
+
+	want := []Deviation{
+		{"a05", "the input XOR of bit 5 is the operand of the carry OR of bit 5, which wants the input AND of bit 5 or the carry AND of bit 5"},
+		{"c03", "the sum XOR of bit 3 is the operand of the carry AND of bit 4, which wants the input XOR of bit 4 or the carry OR of bit 3"},
+		{"c03", "the sum XOR of bit 3 is the operand of the sum XOR of bit 4, which wants the input XOR of bit 4 or the carry OR of bit 3"},
+		{"m02", "x02 XOR y03 doesn't read x and y of the same bit"},
+		{"m02", "the input XOR of an unknown bit drives nothing"},
+		{"n06", "NOT x06 has no role in a ripple-carry adder"},
+		{"s05", "the input AND of bit 5 is the operand of the carry AND of bit 5, which wants the input XOR of bit 5 or the carry OR of bit 4"},
+		{"s05", "the input AND of bit 5 is the operand of the sum XOR of bit 5, which wants the input XOR of bit 5 or the carry OR of bit 4"},
+		{"z03", "the carry OR of bit 3 is the output z03, which wants the sum XOR of bit 3"},
+	}
+
+	for i := range max(len(got), len(want)) {
+		switch {
+		case i >= len(got):
+			t.Errorf("missing %v", want[i])
+		case i >= len(want):
+			t.Errorf("unexpected %v", got[i])
+		case got[i] != want[i]:
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+}
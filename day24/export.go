@@ -0,0 +1,319 @@
+package day24
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(24, registry.Tool{
+		Name:        "export",
+		Description: "render the netlist as a Graphviz or Mermaid graph, grouped by bits",
+		Run:         exportTool,
+	})
+}
+
+type gateStyle struct {
+	shape string
+	color string
+
+	// open and close wrap labels of Mermaid nodes, which sets their shape
+	open, close string
+}
+
+var gateStyles = map[string]gateStyle{
+	"AND":  {shape: "box", color: "lightblue", open: "[", close: "]"},
+	"OR":   {shape: "ellipse", color: "palegreen", open: "(", close: ")"},
+	"XOR":  {shape: "diamond", color: "gold", open: "{", close: "}"},
+	"NOT":  {shape: "invtriangle", color: "pink", open: ">", close: "]"},
+	"NAND": {shape: "box", color: "steelblue", open: "[[", close: "]]"},
+	"NOR":  {shape: "ellipse", color: "darkseagreen", open: "((", close: "))"},
+	"XNOR": {shape: "diamond", color: "orange", open: "[/", close: "/]"},
+}
+
+var defaultGateStyle = gateStyle{shape: "hexagon", color: "lightgrey", open: "{{", close: "}}"}
+
+func styleOf(op Operation) gateStyle {
+	if style, ok := gateStyles[op.String()]; ok {
+		return style
+	}
+
+	return defaultGateStyle
+}
+
+// netlistLayout groups wires by bits of the adder: inputs x_i and y_i belong
+// to bit i, and every gate to the highest bit of its inputs, so that z_i and
+// the carry out of bit i end up next to x_i and y_i. Wires on cycles belong to
+// no bit, since broken netlists have to be drawn too.
+type netlistLayout struct {
+	bits  [][]string
+	other []string
+}
+
+func newNetlistLayout(gates map[string]Gate) (netlistLayout, error) {
+	var layout netlistLayout
+
+	inputs := map[string]int{}
+	for _, gate := range gates {
+		for _, wire := range gate.Inputs {
+			if _, ok := gates[wire]; !ok {
+				inputs[wire] = 0
+			}
+		}
+	}
+
+	bit := map[string]int{}
+
+	// wires on a cycle are taken for inputs of no bit, until the rest of the
+	// netlist can be ordered
+	var order []string
+	for {
+		var err error
+
+		order, err = topologicalOrder(inputs, gates, slices.Sorted(maps.Keys(gates)))
+
+		var cycle *CycleError
+		if !errors.As(err, &cycle) {
+			if err != nil {
+				return netlistLayout{}, err
+			}
+
+			break
+		}
+
+		for _, wire := range cycle.Wires {
+			inputs[wire] = 0
+			bit[wire] = -1
+		}
+	}
+
+	for wire := range inputs {
+		if _, ok := bit[wire]; !ok {
+			bit[wire] = wireBit(wire)
+		}
+	}
+
+	for _, wire := range order {
+		gate := gates[wire]
+		bit[wire] = -1
+		for _, in := range gate.Inputs {
+			bit[wire] = max(bit[wire], bit[in])
+		}
+	}
+
+	for wire, i := range bit {
+		if i < 0 {
+			layout.other = append(layout.other, wire)
+			continue
+		}
+
+		for len(layout.bits) <= i {
+			layout.bits = append(layout.bits, nil)
+		}
+
+		layout.bits[i] = append(layout.bits[i], wire)
+	}
+
+	for _, wires := range layout.bits {
+		slices.Sort(wires)
+	}
+
+	slices.Sort(layout.other)
+
+	return layout, nil
+}
+
+// wireBit returns the bit of an x or y input, or -1 for any other wire.
+func wireBit(wire string) int {
+	if wire == "" || wire[0] != 'x' && wire[0] != 'y' {
+		return -1
+	}
+
+	i, err := strconv.Atoi(wire[1:])
+	if err != nil {
+		return -1
+	}
+
+	return i
+}
+
+// WriteDOT renders the netlist as a Graphviz graph, in which wires are nodes
+// and gates are drawn on the wires they drive. Highlighted wires get a red
+// border.
+func WriteDOT(w io.Writer, gates map[string]Gate, highlight []string) error {
+	layout, err := newNetlistLayout(gates)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph circuit {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [style=filled];")
+
+	node := func(wire string) {
+		gate, ok := gates[wire]
+		if !ok {
+			fmt.Fprintf(bw, "    %s [shape=plaintext, style=\"\"];\n", wire)
+			return
+		}
+
+		style := styleOf(gate.Op)
+
+		attrs := fmt.Sprintf("label=\"%s\\n%s\", shape=%s, fillcolor=%s", wire, gate.Op, style.shape, style.color)
+		if slices.Contains(highlight, wire) {
+			attrs += ", color=red, penwidth=3"
+		}
+
+		fmt.Fprintf(bw, "    %s [%s];\n", wire, attrs)
+	}
+
+	for i, wires := range layout.bits {
+		if len(wires) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(bw, "  subgraph cluster_bit%02d {\n", i)
+		fmt.Fprintf(bw, "    label=\"bit %d\";\n", i)
+
+		for _, wire := range wires {
+			node(wire)
+		}
+
+		fmt.Fprintln(bw, "  }")
+	}
+
+	for _, wire := range layout.other {
+		node(wire)
+	}
+
+	for _, wire := range slices.Sorted(maps.Keys(gates)) {
+		gate := gates[wire]
+		for _, in := range gate.Inputs {
+			fmt.Fprintf(bw, "  %s -> %s;\n", in, wire)
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// WriteMermaid renders the netlist as a Mermaid flowchart, laid out the same
+// way as WriteDOT. Node ids are wires prefixed with w_, since wires may be
+// named like keywords, e.g. end.
+func WriteMermaid(w io.Writer, gates map[string]Gate, highlight []string) error {
+	layout, err := newNetlistLayout(gates)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "flowchart LR")
+
+	node := func(wire string) {
+		gate, ok := gates[wire]
+		if !ok {
+			fmt.Fprintf(bw, "    %s([%s])\n", mermaidID(wire), wire)
+			return
+		}
+
+		style := styleOf(gate.Op)
+		fmt.Fprintf(bw, "    %s%s\"%s %s\"%s\n", mermaidID(wire), style.open, wire, gate.Op, style.close)
+	}
+
+	for i, wires := range layout.bits {
+		if len(wires) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(bw, "  subgraph bit%02d [bit %d]\n", i, i)
+
+		for _, wire := range wires {
+			node(wire)
+		}
+
+		fmt.Fprintln(bw, "  end")
+	}
+
+	for _, wire := range layout.other {
+		node(wire)
+	}
+
+	for _, wire := range slices.Sorted(maps.Keys(gates)) {
+		gate := gates[wire]
+		for _, in := range gate.Inputs {
+			fmt.Fprintf(bw, "  %s --> %s\n", mermaidID(in), mermaidID(wire))
+		}
+	}
+
+	// one class per operator, so that gates of a kind share a colour
+	classes := map[string][]string{}
+	ops := map[string]Operation{}
+	for _, wire := range slices.Sorted(maps.Keys(gates)) {
+		op := gates[wire].Op
+		classes[op.String()] = append(classes[op.String()], mermaidID(wire))
+		ops[op.String()] = op
+	}
+
+	for _, op := range slices.Sorted(maps.Keys(classes)) {
+		fmt.Fprintf(bw, "  classDef %s fill:%s\n", op, styleOf(ops[op]).color)
+		fmt.Fprintf(bw, "  class %s %s\n", strings.Join(classes[op], ","), op)
+	}
+
+	if len(highlight) > 0 {
+		ids := make([]string, len(highlight))
+		for i, wire := range highlight {
+			ids[i] = mermaidID(wire)
+		}
+
+		fmt.Fprintln(bw, "  classDef suspect stroke:red,stroke-width:3px")
+		fmt.Fprintf(bw, "  class %s suspect\n", strings.Join(ids, ","))
+	}
+
+	return bw.Flush()
+}
+
+func mermaidID(wire string) string {
+	return "w_" + wire
+}
+
+func exportTool(args []string, in io.Reader, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "dot", "output format, dot or mermaid")
+	highlight := fs.Bool("highlight", false, "highlight gates suspected by part 2")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, gates, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	var suspects []string
+	if *highlight {
+		suspects = AnalyzeAdder(gates).Suspects()
+	}
+
+	switch *format {
+	case "dot":
+		return WriteDOT(out, gates, suspects)
+	case "mermaid":
+		return WriteMermaid(out, gates, suspects)
+	}
+
+	return fmt.Errorf("unknown format %q", *format)
+}
@@ -0,0 +1,164 @@
+package day24
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(24, registry.Tool{
+		Name:        "verify",
+		Description: "pair up suspect gates, and check which swaps turn the circuit into an adder",
+		Run:         verifyTool,
+	})
+}
+
+// ErrNoFix is returned when no pairing of suspect gates turns the circuit
+// into an adder.
+var ErrNoFix = errors.New("no swaps of the suspect gates fix the adder")
+
+// Swap exchanges outputs of two gates.
+type Swap [2]string
+
+// applySwaps returns a copy of gates with outputs of every pair swapped.
+func applySwaps(gates map[string]Gate, swaps []Swap) map[string]Gate {
+	res := maps.Clone(gates)
+	for _, s := range swaps {
+		res[s[0]], res[s[1]] = res[s[1]], res[s[0]]
+	}
+
+	return res
+}
+
+// pairings yields every way to split wires into pairs.
+func pairings(wires []string) iter.Seq[[]Swap] {
+	return func(yield func([]Swap) bool) {
+		var pair func(rest []string, swaps []Swap) bool
+		pair = func(rest []string, swaps []Swap) bool {
+			if len(rest) == 0 {
+				return yield(slices.Clone(swaps))
+			}
+
+			for i := 1; i < len(rest); i++ {
+				others := slices.Concat(rest[1:i], rest[i+1:])
+				if !pair(others, append(swaps, Swap{rest[0], rest[i]})) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		pair(wires, nil)
+	}
+}
+
+// checkAdder tests that the circuit adds x and y: every bit of an adder
+// depends only on its bits of x and y and the carry from the bit below, so
+// all combinations of the three are tried at every bit. Random pairs are
+// added on top to catch wires crossing between distant bits.
+func checkAdder(gates map[string]Gate) error {
+	adder, err := NewAdder(gates)
+	if err != nil {
+		return err
+	}
+
+	pairs := adder.CarryPairs()
+	pairs = append(pairs, adder.TestPairs(rand.New(rand.NewSource(24)), 1000)...)
+
+	if p, ok := adder.Verify(pairs); !ok {
+		return fmt.Errorf("%d + %d is wrong", p.X, p.Y)
+	}
+
+	return nil
+}
+
+// FixAdder tries every pairing of the suspects, and returns swaps, after which
+// the circuit passes the tests of an adder, or ErrNoFix, when the suspects
+// are wrong.
+func FixAdder(gates map[string]Gate, suspects []string) ([]Swap, error) {
+	if len(suspects)%2 != 0 {
+		return nil, fmt.Errorf("%w: odd number of suspects %v", ErrNoFix, suspects)
+	}
+
+	if err := checkAdder(gates); err == nil {
+		return nil, nil
+	}
+
+	for swaps := range pairings(suspects) {
+		if err := checkAdder(applySwaps(gates, swaps)); err == nil {
+			return swaps, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w %v", ErrNoFix, suspects)
+}
+
+// CarryPairs returns pairs, which set every combination of bits of x and y
+// at every bit, with and without a carry from the bit below.
+func (a *Adder) CarryPairs() []Pair {
+	var pairs []Pair
+
+	for i := range a.x {
+		for _, bits := range [][3]int{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}, {0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1}} {
+			x, y, carry := bits[0], bits[1], bits[2]
+
+			if carry == 1 && i == 0 {
+				continue
+			}
+
+			p := Pair{X: x << i, Y: y << i}
+			if carry == 1 {
+				// both bits below are set, so they carry into the bit
+				p.X |= 1 << (i - 1)
+				p.Y |= 1 << (i - 1)
+			}
+
+			pairs = append(pairs, p)
+		}
+	}
+
+	return pairs
+}
+
+func verifyTool(args []string, in io.Reader, out io.Writer) error {
+	if err := flag.NewFlagSet("verify", flag.ContinueOnError).Parse(args); err != nil {
+		return err
+	}
+
+	_, gates, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	suspects := AnalyzeAdder(gates).Suspects()
+
+	fmt.Fprintf(out, "suspects: %s\n", strings.Join(suspects, ","))
+
+	if err := checkAdder(gates); err == nil {
+		_, err = fmt.Fprintln(out, "the circuit already adds correctly")
+		return err
+	}
+
+	swaps, err := FixAdder(gates, suspects)
+	if err != nil {
+		fmt.Fprintln(out, "the heuristic failed: no pairing of the suspects fixes the adder")
+		return err
+	}
+
+	for _, s := range swaps {
+		fmt.Fprintf(out, "swap %s <-> %s\n", s[0], s[1])
+	}
+
+	_, err = fmt.Fprintf(out, "tested on every bit with all carries, and on random pairs\n")
+	return err
+}
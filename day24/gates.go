@@ -0,0 +1,264 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrArity = errors.New("wrong number of inputs")
+
+// Operation is a kind of gate. Gates take any number of inputs within the
+// arity of their operation.
+type Operation interface {
+	Eval(inputs ...int) int
+
+	// EvalLanes evaluates the operation on 64 vectors of inputs at once.
+	EvalLanes(inputs ...uint64) uint64
+
+	// Arity returns the least and the most inputs the operation takes.
+	Arity() (int, int)
+
+	// String returns the operator as written in the input.
+	String() string
+}
+
+var operations = map[string]Operation{}
+
+func init() {
+	for _, op := range []Operation{
+		OperationAnd{}, OperationOr{}, OperationXor{},
+		OperationNot{}, OperationNand{}, OperationNor{}, OperationXnor{},
+	} {
+		RegisterOperation(op)
+	}
+}
+
+// RegisterOperation makes the operation available to the parser under its
+// name.
+func RegisterOperation(op Operation) {
+	if _, ok := operations[op.String()]; ok {
+		panic(fmt.Sprintf("operation %s is registered twice", op))
+	}
+
+	operations[op.String()] = op
+}
+
+// LookupOperation finds a registered operation by its name.
+func LookupOperation(name string) (Operation, bool) {
+	op, ok := operations[name]
+	return op, ok
+}
+
+func checkArity(op Operation, n int) error {
+	least, most := op.Arity()
+
+	switch {
+	case n != least && least == most:
+		return fmt.Errorf("%w: %s takes %d, got %d", ErrArity, op, least, n)
+	case n < least:
+		return fmt.Errorf("%w: %s takes at least %d, got %d", ErrArity, op, least, n)
+	case n > most:
+		return fmt.Errorf("%w: %s takes at most %d, got %d", ErrArity, op, most, n)
+	}
+
+	return nil
+}
+
+// Gate drives a wire with an operation on its inputs.
+type Gate struct {
+	Op     Operation
+	Inputs []string
+}
+
+// String formats the gate as in the input, without its output: unary gates
+// as prefix operators, and the rest as infix ones.
+func (g Gate) String() string {
+	if len(g.Inputs) == 1 {
+		return g.Op.String() + " " + g.Inputs[0]
+	}
+
+	return strings.Join(g.Inputs, " "+g.Op.String()+" ")
+}
+
+// parseGate parses the left side of a gate definition, which is either
+// infix, like "a AND b AND c", or prefix, like "NOT a" and "AND a b c".
+func parseGate(fields []string) (Gate, error) {
+	if len(fields) == 0 {
+		return Gate{}, errors.New("no inputs")
+	}
+
+	var gate Gate
+
+	if op, ok := operations[fields[0]]; ok {
+		gate = Gate{Op: op, Inputs: fields[1:]}
+	} else {
+		if len(fields) < 3 || len(fields)%2 == 0 {
+			return Gate{}, fmt.Errorf("%w %q", ErrUnknownOperator, strings.Join(fields, " "))
+		}
+
+		name := fields[1]
+		for i := 3; i < len(fields); i += 2 {
+			if fields[i] != name {
+				return Gate{}, fmt.Errorf("operators %s and %s are mixed", name, fields[i])
+			}
+		}
+
+		op, ok := operations[name]
+		if !ok {
+			return Gate{}, fmt.Errorf("%w %q", ErrUnknownOperator, name)
+		}
+
+		gate.Op = op
+		for i := 0; i < len(fields); i += 2 {
+			gate.Inputs = append(gate.Inputs, fields[i])
+		}
+	}
+
+	if err := checkArity(gate.Op, len(gate.Inputs)); err != nil {
+		return Gate{}, err
+	}
+
+	return gate, nil
+}
+
+// variadic is the arity of operations, which take two or more inputs.
+type variadic struct{}
+
+func (variadic) Arity() (int, int) {
+	return 2, math.MaxInt
+}
+
+type OperationAnd struct{ variadic }
+
+func (op OperationAnd) Eval(inputs ...int) int {
+	res := 1
+	for _, in := range inputs {
+		res &= in
+	}
+
+	return res
+}
+
+func (op OperationAnd) EvalLanes(inputs ...uint64) uint64 {
+	res := ^uint64(0)
+	for _, in := range inputs {
+		res &= in
+	}
+
+	return res
+}
+
+func (op OperationAnd) String() string {
+	return "AND"
+}
+
+type OperationOr struct{ variadic }
+
+func (op OperationOr) Eval(inputs ...int) int {
+	res := 0
+	for _, in := range inputs {
+		res |= in
+	}
+
+	return res
+}
+
+func (op OperationOr) EvalLanes(inputs ...uint64) uint64 {
+	var res uint64
+	for _, in := range inputs {
+		res |= in
+	}
+
+	return res
+}
+
+func (op OperationOr) String() string {
+	return "OR"
+}
+
+// OperationXor outputs parity of its inputs.
+type OperationXor struct{ variadic }
+
+func (op OperationXor) Eval(inputs ...int) int {
+	res := 0
+	for _, in := range inputs {
+		res ^= in
+	}
+
+	return res
+}
+
+func (op OperationXor) EvalLanes(inputs ...uint64) uint64 {
+	var res uint64
+	for _, in := range inputs {
+		res ^= in
+	}
+
+	return res
+}
+
+func (op OperationXor) String() string {
+	return "XOR"
+}
+
+type OperationNot struct{}
+
+func (op OperationNot) Eval(inputs ...int) int {
+	return inputs[0] ^ 1
+}
+
+func (op OperationNot) EvalLanes(inputs ...uint64) uint64 {
+	return ^inputs[0]
+}
+
+func (op OperationNot) Arity() (int, int) {
+	return 1, 1
+}
+
+func (op OperationNot) String() string {
+	return "NOT"
+}
+
+type OperationNand struct{ variadic }
+
+func (op OperationNand) Eval(inputs ...int) int {
+	return OperationAnd{}.Eval(inputs...) ^ 1
+}
+
+func (op OperationNand) EvalLanes(inputs ...uint64) uint64 {
+	return ^OperationAnd{}.EvalLanes(inputs...)
+}
+
+func (op OperationNand) String() string {
+	return "NAND"
+}
+
+type OperationNor struct{ variadic }
+
+func (op OperationNor) Eval(inputs ...int) int {
+	return OperationOr{}.Eval(inputs...) ^ 1
+}
+
+func (op OperationNor) EvalLanes(inputs ...uint64) uint64 {
+	return ^OperationOr{}.EvalLanes(inputs...)
+}
+
+func (op OperationNor) String() string {
+	return "NOR"
+}
+
+type OperationXnor struct{ variadic }
+
+func (op OperationXnor) Eval(inputs ...int) int {
+	return OperationXor{}.Eval(inputs...) ^ 1
+}
+
+func (op OperationXnor) EvalLanes(inputs ...uint64) uint64 {
+	return ^OperationXor{}.EvalLanes(inputs...)
+}
+
+func (op OperationXnor) String() string {
+	return "XNOR"
+}
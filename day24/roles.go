@@ -0,0 +1,404 @@
+package day24
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
+)
+
+func init() {
+	registry.RegisterTool(24, registry.Tool{
+		Name:        "roles",
+		Description: "label gates with their roles in a ripple-carry adder, and explain gates out of place",
+		Run:         rolesTool,
+	})
+}
+
+// Role is a part a gate plays in a ripple-carry adder. Bit 0 is a half adder:
+//
+//	x00 XOR y00 -> z00 (half-adder sum)
+//	x00 AND y00 -> c00 (half-adder carry)
+//
+// and every other bit i is a full adder taking the carry out of bit i-1:
+//
+//	xi XOR yi -> si   (input XOR)
+//	xi AND yi -> ai   (input AND)
+//	si XOR c -> zi    (sum XOR)
+//	si AND c -> pi    (carry AND)
+//	ai OR pi -> ci    (carry OR)
+//
+// The carry out of the top bit is the top bit of the sum.
+type Role int
+
+const (
+	RoleUnknown Role = iota
+	RoleHalfSum
+	RoleHalfCarry
+	RoleInputXor
+	RoleInputAnd
+	RoleSumXor
+	RoleCarryAnd
+	RoleCarryOr
+)
+
+var roleNames = [...]string{
+	RoleUnknown:   "gate of no role",
+	RoleHalfSum:   "half-adder sum",
+	RoleHalfCarry: "half-adder carry",
+	RoleInputXor:  "input XOR",
+	RoleInputAnd:  "input AND",
+	RoleSumXor:    "sum XOR",
+	RoleCarryAnd:  "carry AND",
+	RoleCarryOr:   "carry OR",
+}
+
+func (r Role) String() string {
+	return roleNames[r]
+}
+
+// carries reports whether the gate computes the carry out of its bit.
+func (r Role) carries() bool {
+	return r == RoleHalfCarry || r == RoleCarryOr
+}
+
+// Label is the role of a gate in the adder, and the bit it belongs to, which
+// is -1 when the gate has no role or its inputs don't tell the bit.
+type Label struct {
+	Role Role
+	Bit  int
+}
+
+func (l Label) String() string {
+	switch {
+	case l.Role == RoleUnknown:
+		return l.Role.String()
+	case l.Bit < 0:
+		return l.Role.String() + " of an unknown bit"
+	}
+
+	return fmt.Sprintf("%s of bit %d", l.Role, l.Bit)
+}
+
+// Deviation is a wire, which is driven by a gate other than the structure of
+// the adder wants there.
+type Deviation struct {
+	Wire   string
+	Reason string
+}
+
+func (d Deviation) String() string {
+	return d.Wire + ": " + d.Reason
+}
+
+// AdderStructure is the netlist seen as a ripple-carry adder.
+type AdderStructure struct {
+	// Width is the number of bits of x and y.
+	Width int
+
+	// Labels holds the role of the gate driving every wire.
+	Labels map[string]Label
+
+	Deviations []Deviation
+}
+
+// AnalyzeAdder labels every gate by its operation and its inputs alone, and
+// then checks that every wire is driven by the gate, which its readers, or
+// the output bit it is, want. A swap of outputs of two gates puts both
+// wires out of place, so both are reported, while the rest of the netlist
+// stays consistent.
+func AnalyzeAdder(gates map[string]Gate) *AdderStructure {
+	readers := map[string][]string{}
+	inputs := map[string]Gate{}
+
+	for wire, gate := range gates {
+		for _, in := range gate.Inputs {
+			readers[in] = append(readers[in], wire)
+
+			if _, ok := gates[in]; !ok {
+				inputs[in] = Gate{}
+			}
+		}
+	}
+
+	a := &AdderStructure{
+		Width:  len(getWires(inputs, 'x')),
+		Labels: map[string]Label{},
+	}
+
+	wires := slices.Sorted(maps.Keys(gates))
+
+	for _, wire := range wires {
+		a.Labels[wire] = labelInputGate(gates[wire])
+	}
+
+	// bits of gates deeper in the adder follow from bits of their inputs,
+	// preferring gates on x and y, which can't be mislabeled
+	a.inferBits(gates, wires, []Role{RoleSumXor, RoleCarryAnd}, func(in Label) (int, bool) {
+		return in.Bit, in.Role == RoleInputXor
+	})
+	a.inferBits(gates, wires, []Role{RoleCarryOr}, func(in Label) (int, bool) {
+		return in.Bit, in.Role == RoleInputAnd
+	})
+	a.inferBits(gates, wires, []Role{RoleSumXor, RoleCarryAnd}, func(in Label) (int, bool) {
+		return in.Bit + 1, in.Role.carries()
+	})
+	a.inferBits(gates, wires, []Role{RoleCarryOr}, func(in Label) (int, bool) {
+		return in.Bit, in.Role == RoleCarryAnd
+	})
+
+	for _, wire := range wires {
+		slices.Sort(readers[wire])
+		a.check(wire, readers[wire], gates)
+	}
+
+	return a
+}
+
+// labelInputGate labels gates by their operations, and whether they read x
+// and y. Bits are known only for gates on x and y so far.
+func labelInputGate(gate Gate) Label {
+	l := Label{Role: RoleUnknown, Bit: -1}
+
+	if len(gate.Inputs) != 2 {
+		return l
+	}
+
+	lhs, rhs := gate.Inputs[0], gate.Inputs[1]
+	fromInputs := isInput(lhs) && isInput(rhs)
+
+	switch gate.Op.(type) {
+	case OperationXor:
+		l.Role = RoleSumXor
+		if fromInputs {
+			l.Role = RoleInputXor
+		}
+	case OperationAnd:
+		l.Role = RoleCarryAnd
+		if fromInputs {
+			l.Role = RoleInputAnd
+		}
+	case OperationOr:
+		if !fromInputs {
+			l.Role = RoleCarryOr
+		}
+	}
+
+	// x and y of the same bit
+	if !fromInputs || l.Role == RoleUnknown || lhs[0] == rhs[0] || wireBit(lhs) != wireBit(rhs) {
+		return l
+	}
+
+	l.Bit = wireBit(lhs)
+
+	if l.Bit == 0 {
+		switch l.Role {
+		case RoleInputXor:
+			l.Role = RoleHalfSum
+		case RoleInputAnd:
+			l.Role = RoleHalfCarry
+		}
+	}
+
+	return l
+}
+
+func isInput(wire string) bool {
+	return wireBit(wire) >= 0
+}
+
+// inferBits sets bits of gates with the given roles and unknown bits, from
+// the first input, for which bitOf tells the bit.
+func (a *AdderStructure) inferBits(gates map[string]Gate, wires []string, roles []Role, bitOf func(Label) (int, bool)) {
+	for _, wire := range wires {
+		l := a.Labels[wire]
+		if l.Bit >= 0 || !slices.Contains(roles, l.Role) {
+			continue
+		}
+
+		for _, in := range gates[wire].Inputs {
+			if in, ok := a.Labels[in]; ok && in.Bit >= 0 {
+				if bit, ok := bitOf(in); ok {
+					l.Bit = bit
+					break
+				}
+			}
+		}
+
+		a.Labels[wire] = l
+	}
+}
+
+// want describes gates, which may drive a wire in some place of the adder.
+type want struct {
+	roles []Role
+	bits  []int
+	place string
+}
+
+func (w want) accepts(l Label) bool {
+	i := slices.Index(w.roles, l.Role)
+	return i >= 0 && (w.bits[i] < 0 || l.Bit < 0 || w.bits[i] == l.Bit)
+}
+
+func (w want) String() string {
+	var parts []string
+	for i, role := range w.roles {
+		parts = append(parts, Label{Role: role, Bit: w.bits[i]}.String())
+	}
+
+	return strings.Join(parts, " or the ")
+}
+
+// carryInto returns gates, which carry into the bit: the half-adder carry
+// into bit 1, and carry ORs into the rest.
+func carryInto(bit int) ([]Role, []int) {
+	switch {
+	case bit == 1:
+		return []Role{RoleHalfCarry}, []int{0}
+	case bit < 0:
+		return []Role{RoleHalfCarry, RoleCarryOr}, []int{0, -1}
+	}
+
+	return []Role{RoleCarryOr}, []int{bit - 1}
+}
+
+// wantOf returns what the place of the wire wants to drive it: an output bit
+// wants its sum, and readers want their operands.
+func (a *AdderStructure) wantOf(wire string, readers []string) []want {
+	var res []want
+
+	if wire[0] == 'z' {
+		bit := wireBit("x" + wire[1:])
+
+		switch {
+		case bit == 0:
+			res = append(res, want{[]Role{RoleHalfSum}, []int{0}, "output " + wire})
+		case bit > 0 && bit < a.Width:
+			res = append(res, want{[]Role{RoleSumXor}, []int{bit}, "output " + wire})
+		case bit == a.Width:
+			roles, bits := carryInto(bit)
+			res = append(res, want{roles, bits, "output " + wire})
+		}
+	}
+
+	for _, reader := range readers {
+		l := a.Labels[reader]
+
+		switch l.Role {
+		case RoleSumXor, RoleCarryAnd:
+			roles, bits := carryInto(l.Bit)
+			res = append(res, want{append([]Role{RoleInputXor}, roles...), append([]int{l.Bit}, bits...), "operand of the " + l.String()})
+		case RoleCarryOr:
+			res = append(res, want{[]Role{RoleInputAnd, RoleCarryAnd}, []int{l.Bit, l.Bit}, "operand of the " + l.String()})
+		}
+	}
+
+	return res
+}
+
+func (a *AdderStructure) check(wire string, readers []string, gates map[string]Gate) {
+	l := a.Labels[wire]
+
+	deviate := func(reason string, args ...any) {
+		d := Deviation{Wire: wire, Reason: fmt.Sprintf(reason, args...)}
+		if !slices.Contains(a.Deviations, d) {
+			a.Deviations = append(a.Deviations, d)
+		}
+	}
+
+	if l.Role == RoleUnknown {
+		deviate("%s has no role in a ripple-carry adder", gates[wire])
+		return
+	}
+
+	if l.Bit < 0 && !slices.ContainsFunc(gates[wire].Inputs, func(in string) bool { return !isInput(in) }) {
+		deviate("%s doesn't read x and y of the same bit", gates[wire])
+	}
+
+	wants := a.wantOf(wire, readers)
+
+	if len(wants) == 0 && wire[0] != 'z' {
+		deviate("the %s drives nothing", l)
+	}
+
+	for _, w := range wants {
+		if !w.accepts(l) {
+			deviate("the %s is the %s, which wants the %s", l, w.place, w)
+		}
+	}
+}
+
+// Suspects returns wires out of place, in order.
+func (a *AdderStructure) Suspects() []string {
+	var res []string
+	for _, d := range a.Deviations {
+		res = append(res, d.Wire)
+	}
+
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// Bits returns wires grouped by bits of their gates, and wires of unknown
+// bits last.
+func (a *AdderStructure) Bits() [][]string {
+	res := make([][]string, a.Width+1)
+
+	for _, wire := range slices.Sorted(maps.Keys(a.Labels)) {
+		bit := a.Labels[wire].Bit
+		if bit < 0 || bit >= a.Width {
+			bit = a.Width
+		}
+
+		res[bit] = append(res[bit], wire)
+	}
+
+	return res
+}
+
+func rolesTool(args []string, in io.Reader, out io.Writer) error {
+	if err := flag.NewFlagSet("roles", flag.ContinueOnError).Parse(args); err != nil {
+		return err
+	}
+
+	_, gates, err := readInput(in)
+	if err != nil {
+		return fmt.Errorf("readInput: %w", err)
+	}
+
+	a := AnalyzeAdder(gates)
+
+	for i, wires := range a.Bits() {
+		if len(wires) == 0 {
+			continue
+		}
+
+		if i < a.Width {
+			fmt.Fprintf(out, "bit %d:\n", i)
+		} else {
+			fmt.Fprintln(out, "other:")
+		}
+
+		for _, wire := range wires {
+			fmt.Fprintf(out, "  %s = %s: %s\n", wire, gates[wire], a.Labels[wire].Role)
+		}
+	}
+
+	if len(a.Deviations) == 0 {
+		_, err = fmt.Fprintf(out, "the netlist is a %d-bit ripple-carry adder\n", a.Width)
+		return err
+	}
+
+	fmt.Fprintln(out, "deviations:")
+
+	for _, d := range a.Deviations {
+		fmt.Fprintf(out, "  %s\n", d)
+	}
+
+	return nil
+}
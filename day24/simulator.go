@@ -0,0 +1,203 @@
+package day24
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+)
+
+type compiledGate struct {
+	op     Operation
+	inputs []int
+	out    int
+}
+
+// Simulator evaluates a circuit compiled into an array of gates in
+// topological order. Wires are stored in lanes of 64 bits, so that every pass
+// evaluates the circuit on 64 input vectors at once.
+type Simulator struct {
+	inputs  []int
+	outputs []int
+	gates   []compiledGate
+	wires   int
+
+	// arity is the most inputs of any gate
+	arity int
+}
+
+// NewSimulator compiles the part of the circuit, which the outputs depend on.
+func NewSimulator(gates map[string]Gate, inputs, outputs []string) (*Simulator, error) {
+	slots := map[string]int{}
+	slot := func(wire string) int {
+		if i, ok := slots[wire]; ok {
+			return i
+		}
+
+		slots[wire] = len(slots)
+		return slots[wire]
+	}
+
+	s := &Simulator{}
+
+	given := make(map[string]int, len(inputs))
+	for _, wire := range inputs {
+		given[wire] = 0
+		s.inputs = append(s.inputs, slot(wire))
+	}
+
+	order, err := topologicalOrder(given, gates, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wire := range order {
+		gate := gates[wire]
+
+		g := compiledGate{op: gate.Op, out: slot(wire)}
+		for _, in := range gate.Inputs {
+			g.inputs = append(g.inputs, slot(in))
+		}
+
+		s.gates = append(s.gates, g)
+		s.arity = max(s.arity, len(g.inputs))
+	}
+
+	for _, wire := range outputs {
+		s.outputs = append(s.outputs, slot(wire))
+	}
+
+	s.wires = len(slots)
+
+	return s, nil
+}
+
+// Run evaluates the circuit on lanes of the inputs, given in the order of
+// inputs of NewSimulator, and returns lanes of the outputs.
+func (s *Simulator) Run(inputs []uint64) []uint64 {
+	wires := make([]uint64, s.wires)
+	for i, slot := range s.inputs {
+		wires[slot] = inputs[i]
+	}
+
+	args := make([]uint64, s.arity)
+
+	for _, g := range s.gates {
+		for i, slot := range g.inputs {
+			args[i] = wires[slot]
+		}
+
+		wires[g.out] = g.op.EvalLanes(args[:len(g.inputs)]...)
+	}
+
+	outputs := make([]uint64, len(s.outputs))
+	for i, slot := range s.outputs {
+		outputs[i] = wires[slot]
+	}
+
+	return outputs
+}
+
+// Pair holds numbers added by an adder.
+type Pair struct {
+	X, Y int
+}
+
+// Adder is a circuit, which is supposed to add numbers on x wires and y wires,
+// and to output the sum on z wires.
+type Adder struct {
+	x, y, z []string
+	sim     *Simulator
+}
+
+// NewAdder compiles the circuit as an adder of numbers as wide as its x and y
+// inputs.
+func NewAdder(gates map[string]Gate) (*Adder, error) {
+	inputs := map[string]Gate{}
+	for _, gate := range gates {
+		for _, wire := range gate.Inputs {
+			inputs[wire] = Gate{}
+		}
+	}
+
+	a := &Adder{
+		x: getWires(inputs, 'x'),
+		y: getWires(inputs, 'y'),
+		z: getWires(gates, 'z'),
+	}
+
+	if len(a.x) != len(a.y) || len(a.z) > 63 {
+		return nil, fmt.Errorf("can't add %d bits of x to %d bits of y into %d bits of z", len(a.x), len(a.y), len(a.z))
+	}
+
+	sim, err := NewSimulator(gates, slices.Concat(a.x, a.y), a.z)
+	if err != nil {
+		return nil, fmt.Errorf("compile: %w", err)
+	}
+
+	a.sim = sim
+
+	return a, nil
+}
+
+// Bits returns width of the numbers the adder adds.
+func (a *Adder) Bits() int {
+	return len(a.x)
+}
+
+// Verify adds every pair, 64 pairs at a time, and returns the first one with
+// a wrong sum.
+func (a *Adder) Verify(pairs []Pair) (Pair, bool) {
+	inputs := make([]uint64, len(a.x)+len(a.y))
+
+	for start := 0; start < len(pairs); start += 64 {
+		chunk := pairs[start:min(start+64, len(pairs))]
+
+		clear(inputs)
+		for lane, p := range chunk {
+			for i := range a.x {
+				inputs[i] |= uint64(p.X>>i&1) << lane
+				inputs[len(a.x)+i] |= uint64(p.Y>>i&1) << lane
+			}
+		}
+
+		outputs := a.sim.Run(inputs)
+
+		// lanes, in which any bit of z is wrong
+		var wrong uint64
+		for i := range a.z {
+			var want uint64
+			for lane, p := range chunk {
+				want |= uint64((p.X+p.Y)>>i&1) << lane
+			}
+
+			wrong |= outputs[i] ^ want
+		}
+
+		if wrong != 0 {
+			return chunk[bits.TrailingZeros64(wrong)], false
+		}
+	}
+
+	return Pair{}, true
+}
+
+// TestPairs returns pairs exercising the adder: edge cases, where carries run
+// through all bits or through none, every single bit on either input, and n
+// random pairs.
+func (a *Adder) TestPairs(r *rand.Rand, n int) []Pair {
+	limit := 1 << len(a.x)
+	maxValue := limit - 1
+
+	pairs := []Pair{{0, 0}, {maxValue, maxValue}, {maxValue, 1}, {1, maxValue}, {maxValue, 0}, {0, maxValue}}
+
+	for i := range a.x {
+		pairs = append(pairs, Pair{1 << i, 0}, Pair{0, 1 << i}, Pair{1 << i, 1 << i})
+	}
+
+	for range n {
+		pairs = append(pairs, Pair{r.Intn(limit), r.Intn(limit)})
+	}
+
+	return pairs
+}
@@ -1,22 +1,20 @@
-package main
+package day25
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strings"
+
+	"github.com/harmlessevil/advent-of-code-2024/registry"
 )
 
-func main() {
-	if res, err := part1(); err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(res)
-	}
+func init() {
+	registry.Register(25, registry.Part(Part1))
 }
 
-func part1() (int, error) {
-	locks, keys, maxHeight, err := readInput()
+func Part1(r io.Reader) (int, error) {
+	locks, keys, maxHeight, err := readInput(r)
 	if err != nil {
 		return 0, fmt.Errorf("read input: %w", err)
 	}
@@ -44,17 +42,11 @@ func doesKeyFitLock(lock []int, key []int, maxHeight int) bool {
 	return true
 }
 
-func readInput() ([][]int, [][]int, int, error) {
-	f, err := os.Open("input/day-25.txt")
-	if err != nil {
-		return nil, nil, 0, fmt.Errorf("open file: %w", err)
-	}
-	defer f.Close()
-
+func readInput(r io.Reader) ([][]int, [][]int, int, error) {
 	var locks, keys [][]int
 	maxHeight := 0
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		var schematics []string
 
@@ -0,0 +1,61 @@
+package day25
+
+import (
+	"testing"
+
+	"github.com/harmlessevil/advent-of-code-2024/aoctest"
+)
+
+const example = `#####
+.####
+.####
+.####
+.#.#.
+.#...
+.....
+
+#####
+##.##
+.#.##
+...##
+...#.
+...#.
+.....
+
+.....
+#....
+#....
+#...#
+#.#.#
+#.###
+#####
+
+.....
+.....
+#.#..
+###..
+###.#
+###.#
+#####
+
+.....
+.....
+.....
+#....
+#.#..
+#.#.#
+#####`
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 25, []aoctest.Example{
+		{Input: example, Part1: "3"},
+	})
+}
+
+func TestAnswers(t *testing.T) {
+	aoctest.Answers(t, 25)
+}
+
+func BenchmarkParts(b *testing.B) {
+	aoctest.Benchmark(b, 25)
+}
@@ -0,0 +1,229 @@
+// Package search implements shortest path searches over implicit graphs,
+// where nodes are comparable values and edges are produced on demand.
+package search
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// Tree is a result of a search. It holds distance to every reached node, and
+// every predecessor through which a node is reached optimally.
+type Tree[N comparable] struct {
+	Dist map[N]int
+	Prev map[N][]N
+
+	start N
+}
+
+func newTree[N comparable](start N) *Tree[N] {
+	return &Tree[N]{
+		Dist:  map[N]int{start: 0},
+		Prev:  map[N][]N{},
+		start: start,
+	}
+}
+
+// relax records that node can be reached at dist through from, and reports
+// whether the distance to node has improved.
+func (t *Tree[N]) relax(from, node N, dist int) bool {
+	if node == t.start {
+		return false
+	}
+
+	known, ok := t.Dist[node]
+	if ok && dist > known {
+		return false
+	}
+
+	if ok && dist == known {
+		t.Prev[node] = append(t.Prev[node], from)
+		return false
+	}
+
+	t.Dist[node] = dist
+	t.Prev[node] = []N{from}
+
+	return true
+}
+
+// Path returns one of the optimal paths from the start to end, or nil if end
+// wasn't reached.
+func (t *Tree[N]) Path(end N) []N {
+	if _, ok := t.Dist[end]; !ok {
+		return nil
+	}
+
+	path := []N{end}
+	for prev := t.Prev[end]; len(prev) > 0; prev = t.Prev[prev[0]] {
+		path = append(path, prev[0])
+	}
+
+	slices.Reverse(path)
+	return path
+}
+
+// Nodes returns every node that lies on any of the optimal paths from the
+// start to end.
+func (t *Tree[N]) Nodes(end N) map[N]struct{} {
+	if _, ok := t.Dist[end]; !ok {
+		return nil
+	}
+
+	res := map[N]struct{}{end: {}}
+
+	q := []N{end}
+	for len(q) > 0 {
+		to := q[0]
+		q = q[1:]
+
+		for _, from := range t.Prev[to] {
+			if _, ok := res[from]; ok {
+				continue
+			}
+
+			res[from] = struct{}{}
+			q = append(q, from)
+		}
+	}
+
+	return res
+}
+
+// CountPaths returns how many distinct optimal paths lead from the start to
+// end.
+func (t *Tree[N]) CountPaths(end N) int {
+	memo := map[N]int{}
+
+	var count func(N) int
+	count = func(node N) int {
+		if _, ok := t.Dist[node]; !ok {
+			return 0
+		}
+
+		prev := t.Prev[node]
+		if len(prev) == 0 {
+			return 1
+		}
+
+		if c, ok := memo[node]; ok {
+			return c
+		}
+
+		c := 0
+		for _, from := range prev {
+			c += count(from)
+		}
+
+		memo[node] = c
+		return c
+	}
+
+	return count(end)
+}
+
+// BFS explores an unweighted graph breadth-first until it reaches a node
+// satisfying goal. If goal is nil, the whole component of the start is
+// explored. It returns the reached goal node, and whether one was found.
+func BFS[N comparable](start N, neighbours func(N) iter.Seq[N], goal func(N) bool) (N, *Tree[N], bool) {
+	t := newTree(start)
+
+	q := []N{start}
+	for len(q) > 0 {
+		node := q[0]
+		q = q[1:]
+
+		if goal != nil && goal(node) {
+			return node, t, true
+		}
+
+		dist := t.Dist[node] + 1
+		for next := range neighbours(node) {
+			if t.relax(node, next, dist) {
+				q = append(q, next)
+			}
+		}
+	}
+
+	var zero N
+	return zero, t, false
+}
+
+// Dijkstra explores a graph with non-negative edge costs in the order of
+// distance from the start. See BFS for the meaning of goal and results.
+func Dijkstra[N comparable](start N, edges func(N) iter.Seq2[N, int], goal func(N) bool) (N, *Tree[N], bool) {
+	return AStar(start, edges, nil, goal)
+}
+
+// AStar is Dijkstra's algorithm directed towards the goal by a heuristic,
+// which estimates the remaining distance from a node. The heuristic must never
+// overestimate the distance, and must be consistent to track all optimal
+// predecessors correctly. A nil heuristic turns AStar into Dijkstra.
+func AStar[N comparable](start N, edges func(N) iter.Seq2[N, int], heuristic func(N) int, goal func(N) bool) (N, *Tree[N], bool) {
+	t := newTree(start)
+
+	estimate := func(node N, dist int) int {
+		if heuristic == nil {
+			return dist
+		}
+
+		return dist + heuristic(node)
+	}
+
+	q := &queue[N]{{Node: start, Priority: estimate(start, 0)}}
+	for q.Len() > 0 {
+		item := heap.Pop(q).(queueItem[N])
+
+		node := item.Node
+		dist := t.Dist[node]
+		if estimate(node, dist) < item.Priority {
+			// a shorter path to the node was found after the item was queued
+			continue
+		}
+
+		if goal != nil && goal(node) {
+			return node, t, true
+		}
+
+		for next, cost := range edges(node) {
+			if t.relax(node, next, dist+cost) {
+				heap.Push(q, queueItem[N]{Node: next, Priority: estimate(next, dist+cost)})
+			}
+		}
+	}
+
+	var zero N
+	return zero, t, false
+}
+
+type queueItem[N any] struct {
+	Node     N
+	Priority int
+}
+
+type queue[N any] []queueItem[N]
+
+func (q queue[N]) Len() int {
+	return len(q)
+}
+
+func (q queue[N]) Less(i, j int) bool {
+	return q[i].Priority < q[j].Priority
+}
+
+func (q queue[N]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *queue[N]) Push(x any) {
+	*q = append(*q, x.(queueItem[N]))
+}
+
+func (q *queue[N]) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[0 : n-1]
+	return x
+}
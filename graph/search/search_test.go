@@ -0,0 +1,103 @@
+package search
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+type graph map[string]map[string]int
+
+func (g graph) edges(node string) iter.Seq2[string, int] {
+	return maps.All(g[node])
+}
+
+func (g graph) neighbours(node string) iter.Seq[string] {
+	return maps.Keys(g[node])
+}
+
+// diamond has two equally short paths from a to d, and a longer detour
+// through e.
+var diamond = graph{
+	"a": {"b": 1, "c": 1, "e": 1},
+	"b": {"d": 1},
+	"c": {"d": 1},
+	"e": {"f": 1},
+	"f": {"d": 1},
+}
+
+func isD(node string) bool {
+	return node == "d"
+}
+
+func TestBFS(t *testing.T) {
+	end, tree, ok := BFS("a", diamond.neighbours, isD)
+	if !ok || end != "d" {
+		t.Fatalf("got %q, %t", end, ok)
+	}
+
+	if got := tree.Dist["d"]; got != 2 {
+		t.Errorf("Dist: got %d, want 2", got)
+	}
+
+	if got := tree.CountPaths("d"); got != 2 {
+		t.Errorf("CountPaths: got %d, want 2", got)
+	}
+
+	if got := slices.Sorted(maps.Keys(tree.Nodes("d"))); !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Nodes: got %v", got)
+	}
+
+	if got := tree.Path("d"); len(got) != 3 || got[0] != "a" || got[2] != "d" {
+		t.Errorf("Path: got %v", got)
+	}
+}
+
+func TestBFSUnreachable(t *testing.T) {
+	_, tree, ok := BFS("b", diamond.neighbours, func(node string) bool { return node == "a" })
+	if ok {
+		t.Fatal("found unreachable node")
+	}
+
+	if got := tree.Path("a"); got != nil {
+		t.Errorf("Path: got %v, want nil", got)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	// the detour is cheaper than any of the short paths
+	g := graph{
+		"a": {"b": 1, "c": 1, "e": 1},
+		"b": {"d": 10},
+		"c": {"d": 10},
+		"e": {"f": 1},
+		"f": {"d": 1},
+	}
+
+	_, tree, ok := Dijkstra("a", g.edges, isD)
+	if !ok {
+		t.Fatal("d is not reached")
+	}
+
+	if got := tree.Dist["d"]; got != 3 {
+		t.Errorf("Dist: got %d, want 3", got)
+	}
+
+	if got := tree.Path("d"); !slices.Equal(got, []string{"a", "e", "f", "d"}) {
+		t.Errorf("Path: got %v", got)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	remaining := map[string]int{"a": 2, "b": 1, "c": 1, "e": 2, "f": 1}
+
+	_, tree, ok := AStar("a", diamond.edges, func(node string) int { return remaining[node] }, isD)
+	if !ok {
+		t.Fatal("d is not reached")
+	}
+
+	if got := tree.CountPaths("d"); got != 2 {
+		t.Errorf("CountPaths: got %d, want 2", got)
+	}
+}
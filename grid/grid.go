@@ -0,0 +1,129 @@
+// Package grid provides two-dimensional grids of cells, which half of the
+// puzzles are played on.
+package grid
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"slices"
+)
+
+// Grid is a rectangular grid indexed by row first.
+type Grid[T any] [][]T
+
+func New[T any](rows, cols int) Grid[T] {
+	g := make(Grid[T], rows)
+	for i := range g {
+		g[i] = make([]T, cols)
+	}
+
+	return g
+}
+
+// Parse reads a grid of bytes line by line until the end of input or until an
+// empty line. The reader is buffered, so it may be consumed past the empty
+// line; use Scan to read the rest of the input too.
+func Parse(r io.Reader) (Grid[byte], error) {
+	return Scan(bufio.NewScanner(r))
+}
+
+// Scan reads a grid of bytes like Parse, but from lines of the scanner, which
+// is left right after the empty line.
+func Scan(scanner *bufio.Scanner) (Grid[byte], error) {
+	var g Grid[byte]
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			break
+		}
+
+		g = append(g, slices.Clone(line))
+	}
+
+	return g, scanner.Err()
+}
+
+func (g Grid[T]) Rows() int {
+	return len(g)
+}
+
+func (g Grid[T]) Cols() int {
+	if len(g) == 0 {
+		return 0
+	}
+
+	return len(g[0])
+}
+
+func (g Grid[T]) InBounds(v Vec2D) bool {
+	return v.Row >= 0 && v.Row < len(g) && v.Col >= 0 && v.Col < len(g[v.Row])
+}
+
+func (g Grid[T]) At(v Vec2D) *T {
+	return &g[v.Row][v.Col]
+}
+
+func (g Grid[T]) Get(v Vec2D) T {
+	return g[v.Row][v.Col]
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	res := make(Grid[T], len(g))
+	for i, row := range g {
+		res[i] = slices.Clone(row)
+	}
+
+	return res
+}
+
+// All iterates over every cell of the grid row by row.
+func (g Grid[T]) All() iter.Seq2[Vec2D, T] {
+	return func(yield func(Vec2D, T) bool) {
+		for row, line := range g {
+			for col, cell := range line {
+				if !yield(Vec2D{Row: row, Col: col}, cell) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbours4 iterates over orthogonally adjacent cells that are in bounds.
+func (g Grid[T]) Neighbours4(v Vec2D) iter.Seq[Vec2D] {
+	return g.neighbours(v, Directions4)
+}
+
+// Neighbours8 iterates over orthogonally and diagonally adjacent cells that
+// are in bounds.
+func (g Grid[T]) Neighbours8(v Vec2D) iter.Seq[Vec2D] {
+	return g.neighbours(v, Directions8)
+}
+
+func (g Grid[T]) neighbours(v Vec2D, directions []Dir2D) iter.Seq[Vec2D] {
+	return func(yield func(Vec2D) bool) {
+		for _, dir := range directions {
+			next := v.Add(dir)
+			if !g.InBounds(next) {
+				continue
+			}
+
+			if !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns position of the first cell equal to value.
+func Find[T comparable](g Grid[T], value T) (Vec2D, bool) {
+	for pos, cell := range g.All() {
+		if cell == value {
+			return pos, true
+		}
+	}
+
+	return Vec2D{}, false
+}
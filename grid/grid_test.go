@@ -0,0 +1,100 @@
+package grid
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	g, err := Parse(strings.NewReader("#.#\n..S\n\nignored\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("got %dx%d grid, want 2x3", g.Rows(), g.Cols())
+	}
+
+	if pos, ok := Find(g, 'S'); !ok || pos != (Vec2D{Row: 1, Col: 2}) {
+		t.Errorf("Find: got %v, %t", pos, ok)
+	}
+}
+
+func TestScan(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("#.#\n..S\n\nrest\n"))
+
+	g, err := Scan(scanner)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("got %dx%d grid, want 2x3", g.Rows(), g.Cols())
+	}
+
+	if !scanner.Scan() || scanner.Text() != "rest" {
+		t.Errorf("got %q after the grid, want %q", scanner.Text(), "rest")
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 3)
+
+	for _, tc := range []struct {
+		name string
+		got  []Vec2D
+		want []Vec2D
+	}{
+		{
+			name: "4/corner",
+			got:  slices.Collect(g.Neighbours4(Vec2D{})),
+			want: []Vec2D{{Row: 0, Col: 1}, {Row: 1, Col: 0}},
+		},
+		{
+			name: "8/corner",
+			got:  slices.Collect(g.Neighbours8(Vec2D{Row: 2, Col: 2})),
+			want: []Vec2D{{Row: 1, Col: 2}, {Row: 2, Col: 1}, {Row: 1, Col: 1}},
+		},
+		{
+			name: "8/center",
+			got:  slices.Collect(g.Neighbours8(Vec2D{Row: 1, Col: 1})),
+			want: []Vec2D{
+				{Row: 0, Col: 1}, {Row: 0, Col: 2}, {Row: 1, Col: 2}, {Row: 2, Col: 2},
+				{Row: 2, Col: 1}, {Row: 2, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: 0},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if !slices.Equal(tc.got, tc.want) {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for i, dir := range Directions4 {
+		next := Directions4[(i+1)%len(Directions4)]
+
+		if got := dir.RotateClockwise(); got != next {
+			t.Errorf("%v.RotateClockwise() = %v, want %v", dir, got, next)
+		}
+
+		if got := next.RotateCounterclockwise(); got != dir {
+			t.Errorf("%v.RotateCounterclockwise() = %v, want %v", next, got, dir)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := New[byte](2, 2)
+
+	c := g.Clone()
+	*c.At(Vec2D{Row: 1, Col: 1}) = '#'
+
+	if g.Get(Vec2D{Row: 1, Col: 1}) != 0 {
+		t.Error("modifying a clone changed the original grid")
+	}
+}
@@ -0,0 +1,80 @@
+package grid
+
+type Vec2D struct {
+	Row int
+	Col int
+}
+
+func (v Vec2D) Add(d Dir2D) Vec2D {
+	return Vec2D{Row: v.Row + d.Row, Col: v.Col + d.Col}
+}
+
+func (v Vec2D) Sub(d Dir2D) Vec2D {
+	return Vec2D{Row: v.Row - d.Row, Col: v.Col - d.Col}
+}
+
+// To returns direction that leads from v to other.
+func (v Vec2D) To(other Vec2D) Dir2D {
+	return Dir2D{Row: other.Row - v.Row, Col: other.Col - v.Col}
+}
+
+func (v Vec2D) ManhattanDistance(other Vec2D) int {
+	return abs(v.Row-other.Row) + abs(v.Col-other.Col)
+}
+
+type Dir2D Vec2D
+
+var (
+	DirectionUp    = Dir2D{Row: -1, Col: 0}
+	DirectionRight = Dir2D{Row: 0, Col: 1}
+	DirectionDown  = Dir2D{Row: 1, Col: 0}
+	DirectionLeft  = Dir2D{Row: 0, Col: -1}
+
+	DirectionRightUp   = Dir2D{Row: -1, Col: 1}
+	DirectionRightDown = Dir2D{Row: 1, Col: 1}
+	DirectionLeftDown  = Dir2D{Row: 1, Col: -1}
+	DirectionLeftUp    = Dir2D{Row: -1, Col: -1}
+)
+
+var (
+	// Directions4 lists orthogonal directions clockwise starting from up.
+	Directions4 = []Dir2D{DirectionUp, DirectionRight, DirectionDown, DirectionLeft}
+
+	// Directions8 lists orthogonal and diagonal directions clockwise starting from up.
+	Directions8 = []Dir2D{
+		DirectionUp, DirectionRightUp, DirectionRight, DirectionRightDown,
+		DirectionDown, DirectionLeftDown, DirectionLeft, DirectionLeftUp,
+	}
+)
+
+func (d Dir2D) Add(other Dir2D) Dir2D {
+	return Dir2D{Row: d.Row + other.Row, Col: d.Col + other.Col}
+}
+
+func (d Dir2D) Neg() Dir2D {
+	return Dir2D{Row: -d.Row, Col: -d.Col}
+}
+
+func (d Dir2D) RotateClockwise() Dir2D {
+	return Dir2D{Row: d.Col, Col: -d.Row}
+}
+
+func (d Dir2D) RotateCounterclockwise() Dir2D {
+	return Dir2D{Row: -d.Col, Col: d.Row}
+}
+
+func (d Dir2D) IsHorizontal() bool {
+	return d.Row == 0 && d.Col != 0
+}
+
+func (d Dir2D) IsVertical() bool {
+	return d.Col == 0 && d.Row != 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Solver computes an answer to a single part of a puzzle given its input.
+type Solver func(io.Reader) (any, error)
+
+// Part adapts a typed part solution to a Solver, so that days returning
+// numbers and days returning strings can share the same registry.
+func Part[T any](f func(io.Reader) (T, error)) Solver {
+	return func(r io.Reader) (any, error) {
+		return f(r)
+	}
+}
+
+// Tool is a command that a day provides besides solving the puzzle, e.g. to
+// inspect its input. It reads puzzle input from in, and parses its own
+// arguments.
+type Tool struct {
+	Name        string
+	Description string
+	Run         func(args []string, in io.Reader, out io.Writer) error
+
+	// Stdin makes the runner read stdin instead of the puzzle input by
+	// default, for tools which read something else.
+	Stdin bool
+}
+
+type Day struct {
+	Number int
+	Parts  []Solver
+	Tools  []Tool
+}
+
+var (
+	days  = map[int]Day{}
+	tools = map[int][]Tool{}
+)
+
+// Register makes solvers for the given day available to the runner. It is
+// meant to be called from init functions of the day packages.
+func Register(number int, parts ...Solver) {
+	if _, ok := days[number]; ok {
+		panic(fmt.Sprintf("day %d is registered twice", number))
+	}
+
+	days[number] = Day{Number: number, Parts: parts}
+}
+
+// RegisterTool makes a tool of the given day available to the runner. Like
+// Register, it is meant to be called from init functions, which may run in any
+// order within a package.
+func RegisterTool(number int, tool Tool) {
+	tools[number] = append(tools[number], tool)
+}
+
+func Lookup(number int) (Day, bool) {
+	day, ok := days[number]
+	day.Tools = tools[number]
+
+	return day, ok
+}
+
+// All returns every registered day in ascending order.
+func All() []Day {
+	res := slices.SortedFunc(maps.Values(days), func(a, b Day) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	for i := range res {
+		res[i].Tools = tools[res[i].Number]
+	}
+
+	return res
+}
+
+// LookupTool finds a tool of the day by name.
+func (d Day) LookupTool(name string) (Tool, bool) {
+	for _, tool := range d.Tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+
+	return Tool{}, false
+}